@@ -23,10 +23,12 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -118,6 +120,26 @@ func main() {
 	flag.BoolVar(&enableParallelAPICalls, "enable-parallel-api-calls", true,
 		"Enable parallel API calls where possible to reduce failover time. Default: true.")
 
+	var enableNodeAwareness bool
+	flag.BoolVar(&enableNodeAwareness, "enable-node-awareness", false,
+		"Allow Services to use Node state (zen-lead.io/preferred-zones, -regions, -node-selector, zen-lead.io/unsafe-node-policy) and publish endpoint zones. Requires config/rbac/node-awareness. Default: false (Nodes are not cached or watched).")
+
+	var roleProbeConcurrency int
+	flag.IntVar(&roleProbeConcurrency, "role-probe-concurrency", 10,
+		"Maximum number of parallel application role probes per Service (zen-lead.io/role-probe). Default: 10.")
+
+	var enableRoleProbeSecrets bool
+	flag.BoolVar(&enableRoleProbeSecrets, "enable-role-probe-secrets", false,
+		"Allow role probes to read credentials from Secrets labelled zen-lead.io/role-probe-credentials=true (zen-lead.io/role-probe-secret). Requires config/rbac/role-probe-secrets. Default: false (no Secret access).")
+
+	var enableFollowLease bool
+	flag.BoolVar(&enableFollowLease, "enable-follow-lease", false,
+		"Allow Services to follow a coordination.k8s.io Lease holder (zen-lead.io/follow-lease). Caches and watches Leases cluster-wide. Default: false (Leases are not cached or watched).")
+
+	var enableRolePublishing bool
+	flag.BoolVar(&enableRolePublishing, "enable-role-publishing", false,
+		"Allow Services to publish leader/follower roles onto pods (zen-lead.io/publish-role). Requires config/rbac/role-publishing. Default: false (no pod mutation).")
+
 	flag.Parse()
 
 	// Initialize zen-sdk logger (configures controller-runtime logger automatically)
@@ -173,6 +195,11 @@ func main() {
 			BindAddress: metricsAddr,
 		},
 		HealthProbeBindAddress: probeAddr,
+		// Role probe Secrets and leader epoch ConfigMaps are read on demand: caching them would need
+		// cluster-wide list/watch, and the epoch high-water mark must never be read stale
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}}},
+		},
 	}
 
 	// Apply leader election (mandatory for zen-lead - always enabled, no option to disable)
@@ -203,6 +230,11 @@ func main() {
 		time.Duration(leaderPodCacheTTLSeconds)*time.Second,
 		enableParallelAPICalls,
 	)
+	reconciler.SetNodeAwareness(enableNodeAwareness)
+	reconciler.SetRoleProbeConcurrency(roleProbeConcurrency)
+	reconciler.SetRoleProbeSecrets(enableRoleProbeSecrets)
+	reconciler.SetFollowLease(enableFollowLease)
+	reconciler.SetRolePublishing(enableRolePublishing)
 	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", sdklog.Component("ServiceDirector"), sdklog.ErrorCode("CONTROLLER_SETUP_ERROR"))
 		os.Exit(1)
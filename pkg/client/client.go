@@ -24,11 +24,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,8 +39,22 @@ import (
 const (
 	// DefaultCacheTTL is the default cache TTL for leader status
 	DefaultCacheTTL = 2 * time.Second
+
+	// Leader Service annotations maintained by the zen-lead controller (see pkg/director)
+	AnnotationLeaderPodName = "zen-lead.io/leader-pod-name"
+	AnnotationLeaderPodUID  = "zen-lead.io/leader-pod-uid"
+	AnnotationLeaderEpoch   = "zen-lead.io/leader-epoch"
 )
 
+// LeaderInfo is the leader of a zen-lead leader Service
+type LeaderInfo struct {
+	// PodName and PodUID identify the leader pod (empty when there is no leader)
+	PodName string
+	PodUID  string
+	// Epoch increases on every leader change and never decreases: a fencing token
+	Epoch uint64
+}
+
 // Client provides a simple API for checking leader status
 // This is the "Simple Query" API that tools use to ask "Am I the leader?"
 type Client struct {
@@ -230,6 +246,47 @@ func (c *Client) IsLeaderWithNamespace(ctx context.Context, poolName, namespace
 	return isLeader, nil
 }
 
+// GetLeaderInfo reads the current leader and leader epoch from a zen-lead leader Service
+// (e.g. "my-app-leader"). It is never cached: use the epoch as a fencing token by attaching it to
+// writes, and have the downstream system reject writes with an epoch lower than the highest it has seen.
+//
+// Requires get permission on services in the namespace.
+func (c *Client) GetLeaderInfo(ctx context.Context, leaderServiceName, namespace string) (*LeaderInfo, error) {
+	namespace = strings.TrimSpace(namespace)
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid namespace format: %v", errs)
+	}
+
+	svc := &corev1.Service{}
+	if err := c.k8sClient.Get(ctx, types.NamespacedName{Name: leaderServiceName, Namespace: namespace}, svc); err != nil {
+		return nil, fmt.Errorf("leader service %s not found in namespace %s (zen-lead may not be installed): %w", leaderServiceName, namespace, err)
+	}
+
+	info := &LeaderInfo{
+		PodName: svc.Annotations[AnnotationLeaderPodName],
+		PodUID:  svc.Annotations[AnnotationLeaderPodUID],
+	}
+	if val, ok := svc.Annotations[AnnotationLeaderEpoch]; ok {
+		epoch, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q on leader service %s/%s: %w", AnnotationLeaderEpoch, val, namespace, leaderServiceName, err)
+		}
+		info.Epoch = epoch
+	}
+	return info, nil
+}
+
+// IsSelf reports whether info names the current pod (by UID when POD_UID is set, else by name)
+func (c *Client) IsSelf(info *LeaderInfo) bool {
+	if info == nil || info.PodName == "" || c.podName == "" {
+		return false
+	}
+	if c.podUID != "" && info.PodUID != "" {
+		return c.podUID == info.PodUID
+	}
+	return c.podName == info.PodName
+}
+
 // ClearCache clears the leader status cache
 // Useful for testing or when you want to force a fresh check
 func (c *Client) ClearCache() {
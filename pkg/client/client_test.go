@@ -0,0 +1,190 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClient_GetLeaderInfo(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		missing     bool
+		expected    *LeaderInfo
+		expectError bool
+	}{
+		{
+			name: "epoch present",
+			annotations: map[string]string{
+				AnnotationLeaderPodName: "my-app-0",
+				AnnotationLeaderPodUID:  "uid-0",
+				AnnotationLeaderEpoch:   "42",
+			},
+			expected: &LeaderInfo{PodName: "my-app-0", PodUID: "uid-0", Epoch: 42},
+		},
+		{
+			name: "epoch absent",
+			annotations: map[string]string{
+				AnnotationLeaderPodName: "my-app-0",
+				AnnotationLeaderPodUID:  "uid-0",
+			},
+			expected: &LeaderInfo{PodName: "my-app-0", PodUID: "uid-0"},
+		},
+		{
+			name:     "no leader",
+			expected: &LeaderInfo{},
+		},
+		{
+			name: "epoch malformed",
+			annotations: map[string]string{
+				AnnotationLeaderPodName: "my-app-0",
+				AnnotationLeaderEpoch:   "forty-two",
+			},
+			expectError: true,
+		},
+		{
+			name: "epoch negative",
+			annotations: map[string]string{
+				AnnotationLeaderPodName: "my-app-0",
+				AnnotationLeaderEpoch:   "-1",
+			},
+			expectError: true,
+		},
+		{
+			name:        "leader service missing",
+			missing:     true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if !tt.missing {
+				builder = builder.WithObjects(&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "my-app-leader",
+						Namespace:   "default",
+						Annotations: tt.annotations,
+					},
+				})
+			}
+			c := &Client{k8sClient: builder.Build(), cache: make(map[string]cacheEntry)}
+
+			info, err := c.GetLeaderInfo(context.Background(), "my-app-leader", "default")
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("GetLeaderInfo() = %+v, expected an error", info)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetLeaderInfo() error = %v", err)
+			}
+			if *info != *tt.expected {
+				t.Errorf("GetLeaderInfo() = %+v, expected %+v", info, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClient_GetLeaderInfo_InvalidNamespace(t *testing.T) {
+	c := &Client{k8sClient: fake.NewClientBuilder().Build(), cache: make(map[string]cacheEntry)}
+	if _, err := c.GetLeaderInfo(context.Background(), "my-app-leader", "Not_A_Namespace"); err == nil {
+		t.Error("GetLeaderInfo() expected an error for an invalid namespace")
+	}
+}
+
+func TestClient_IsSelf(t *testing.T) {
+	tests := []struct {
+		name     string
+		podName  string
+		podUID   string
+		info     *LeaderInfo
+		expected bool
+	}{
+		{
+			name:     "self by UID",
+			podName:  "my-app-0",
+			podUID:   "uid-0",
+			info:     &LeaderInfo{PodName: "my-app-0", PodUID: "uid-0", Epoch: 3},
+			expected: true,
+		},
+		{
+			name:     "other pod",
+			podName:  "my-app-0",
+			podUID:   "uid-0",
+			info:     &LeaderInfo{PodName: "my-app-1", PodUID: "uid-1", Epoch: 3},
+			expected: false,
+		},
+		{
+			name:     "same name, recreated pod",
+			podName:  "my-app-0",
+			podUID:   "uid-0",
+			info:     &LeaderInfo{PodName: "my-app-0", PodUID: "uid-0-old", Epoch: 3},
+			expected: false,
+		},
+		{
+			name:     "self by name without POD_UID",
+			podName:  "my-app-0",
+			info:     &LeaderInfo{PodName: "my-app-0", PodUID: "uid-0", Epoch: 3},
+			expected: true,
+		},
+		{
+			name:     "other pod by name without POD_UID",
+			podName:  "my-app-0",
+			info:     &LeaderInfo{PodName: "my-app-1", PodUID: "uid-1", Epoch: 3},
+			expected: false,
+		},
+		{
+			name:     "no leader",
+			podName:  "my-app-0",
+			podUID:   "uid-0",
+			info:     &LeaderInfo{},
+			expected: false,
+		},
+		{
+			name:     "nil info",
+			podName:  "my-app-0",
+			expected: false,
+		},
+		{
+			name:     "pod name unknown",
+			info:     &LeaderInfo{PodName: "my-app-0"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{podName: tt.podName, podUID: tt.podUID}
+			if got := c.IsSelf(tt.info); got != tt.expected {
+				t.Errorf("IsSelf() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -42,6 +42,17 @@ limitations under the License.
 //
 //	// Proceed with leader-only logic
 //
+// Fencing (Service-based routing):
+//
+// The leader Service carries a monotonically increasing leader epoch. Read it fresh and attach it to
+// writes so that storage can reject a deposed leader:
+//
+//	info, err := zenleadClient.GetLeaderInfo(ctx, "my-app-leader", namespace)
+//	if err != nil || !zenleadClient.IsSelf(info) {
+//		// not the leader - do not write
+//	}
+//	write(data, info.Epoch) // storage rejects epochs lower than the highest it has seen
+//
 // Fail-Safe Behavior:
 //
 // If zen-lead is not installed (Lease doesn't exist), IsLeader() returns true.
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// recordAnnotationWarning emits a Warning event for a misconfigured annotation, but only when the
+// message differs from the last one recorded for that service and annotation. Reconciles run on
+// every pod change, so without this a single bad annotation floods the Service's events.
+func (r *ServiceDirectorReconciler) recordAnnotationWarning(svc *corev1.Service, annotation, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+
+	r.annotationWarningsMu.Lock()
+	if r.annotationWarnings == nil {
+		r.annotationWarnings = make(map[string]map[string]string)
+	}
+	if r.annotationWarnings[serviceKey] == nil {
+		r.annotationWarnings[serviceKey] = make(map[string]string)
+	}
+	if r.annotationWarnings[serviceKey][annotation] == message {
+		r.annotationWarningsMu.Unlock()
+		return
+	}
+	r.annotationWarnings[serviceKey][annotation] = message
+	r.annotationWarningsMu.Unlock()
+
+	r.Recorder.Event(svc, corev1.EventTypeWarning, reason, message)
+}
+
+// clearAnnotationWarning forgets the warning for an annotation once its config is valid again,
+// so a later regression is reported anew
+func (r *ServiceDirectorReconciler) clearAnnotationWarning(svc *corev1.Service, annotation string) {
+	serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+
+	r.annotationWarningsMu.Lock()
+	defer r.annotationWarningsMu.Unlock()
+	delete(r.annotationWarnings[serviceKey], annotation)
+	if len(r.annotationWarnings[serviceKey]) == 0 {
+		delete(r.annotationWarnings, serviceKey)
+	}
+}
+
+// forgetAnnotationWarnings drops all recorded warnings for a service (opt-out or deletion)
+func (r *ServiceDirectorReconciler) forgetAnnotationWarnings(serviceKey string) {
+	r.annotationWarningsMu.Lock()
+	defer r.annotationWarningsMu.Unlock()
+	delete(r.annotationWarnings, serviceKey)
+}
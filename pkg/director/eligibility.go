@@ -0,0 +1,217 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationEligibilityService selects which pod state makes a pod eligible for leadership
+	AnnotationEligibilityService = "zen-lead.io/eligibility"
+
+	// EligibilityReady requires phase Running and PodReady=True (default)
+	EligibilityReady = "ready"
+	// EligibilityContainersReadyPrefix requires phase Running and readiness of the named container only
+	// (e.g. "containers-ready:postgres"), so sidecar readiness does not block leadership
+	EligibilityContainersReadyPrefix = "containers-ready:"
+	// EligibilityRunning requires phase Running only, for apps that turn Ready after being routed as primary
+	EligibilityRunning = "running"
+)
+
+// podEligibility decides whether a pod may be leader (or active/follower) for a Service
+type podEligibility struct {
+	mode              string
+	container         string                  // only for containers-ready
+	requiredCondition corev1.PodConditionType // zen-lead.io/required-condition (optional)
+}
+
+// parsePodEligibility parses zen-lead.io/eligibility. Invalid values return the ready mode and an error.
+func parsePodEligibility(svc *corev1.Service) (podEligibility, error) {
+	if svc.Annotations == nil {
+		return podEligibility{mode: EligibilityReady}, nil
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationEligibilityService])
+	switch {
+	case val == "" || val == EligibilityReady:
+		return podEligibility{mode: EligibilityReady}, nil
+	case val == EligibilityRunning:
+		return podEligibility{mode: EligibilityRunning}, nil
+	case strings.HasPrefix(val, EligibilityContainersReadyPrefix):
+		container := strings.TrimSpace(strings.TrimPrefix(val, EligibilityContainersReadyPrefix))
+		if container == "" {
+			return podEligibility{mode: EligibilityReady}, fmt.Errorf("container name is required after %q", EligibilityContainersReadyPrefix)
+		}
+		return podEligibility{mode: EligibilityContainersReadyPrefix, container: container}, nil
+	default:
+		return podEligibility{mode: EligibilityReady}, fmt.Errorf("unknown mode %q", val)
+	}
+}
+
+// getPodEligibility returns the eligibility criteria for a Service.
+// Invalid values emit a Warning event and fall back to ready (and no required condition).
+func (r *ServiceDirectorReconciler) getPodEligibility(svc *corev1.Service) podEligibility {
+	eligibility, err := parsePodEligibility(svc)
+	if err != nil {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidEligibility",
+			fmt.Sprintf("Invalid %s: %v (supported: %s, %s<container>, %s). Using %s.",
+				AnnotationEligibilityService, err, EligibilityReady, EligibilityContainersReadyPrefix, EligibilityRunning, EligibilityReady))
+	}
+	eligibility.requiredCondition, err = parseRequiredCondition(svc)
+	if err != nil {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidRequiredCondition",
+			fmt.Sprintf("Invalid %s: %v. No pod condition is required.", AnnotationRequiredConditionService, err))
+	}
+	return eligibility
+}
+
+// getServiceEligibility returns the eligibility criteria without emitting events (invalid values use ready)
+func getServiceEligibility(svc *corev1.Service) podEligibility {
+	eligibility, _ := parsePodEligibility(svc)
+	eligibility.requiredCondition, _ = parseRequiredCondition(svc)
+	return eligibility
+}
+
+// isEligible reports whether the pod satisfies the eligibility criteria
+func (e podEligibility) isEligible(pod *corev1.Pod) bool {
+	return e.modeEligible(pod) && e.hasRequiredCondition(pod)
+}
+
+// modeEligible reports whether the pod satisfies the zen-lead.io/eligibility mode
+func (e podEligibility) modeEligible(pod *corev1.Pod) bool {
+	switch e.mode {
+	case EligibilityRunning:
+		return pod.Status.Phase == corev1.PodRunning
+	case EligibilityContainersReadyPrefix:
+		if pod.Status.Phase != corev1.PodRunning {
+			return false
+		}
+		status := findContainerStatus(pod, e.container)
+		return status != nil && status.Ready
+	default:
+		return isPodReady(pod)
+	}
+}
+
+// eligibleSince returns when the pod became eligible (for zen-lead.io/min-ready-duration), or nil if unknown
+func (e podEligibility) eligibleSince(pod *corev1.Pod) *time.Time {
+	since := e.modeEligibleSince(pod)
+	if since == nil || e.requiredCondition == "" {
+		return since
+	}
+	// The required condition may have turned True after the mode criteria were met
+	condition := findPodCondition(pod, e.requiredCondition)
+	if condition == nil || condition.Status != corev1.ConditionTrue {
+		return nil
+	}
+	if condition.LastTransitionTime.After(*since) {
+		return &condition.LastTransitionTime.Time
+	}
+	return since
+}
+
+// modeEligibleSince returns when the pod met the zen-lead.io/eligibility mode, or nil if unknown.
+// Container readiness has no transition time, so containers-ready uses the container's start time.
+func (e podEligibility) modeEligibleSince(pod *corev1.Pod) *time.Time {
+	switch e.mode {
+	case EligibilityRunning:
+		// Latest container start - the pod has been fully running since then
+		var since *time.Time
+		for i := range pod.Status.ContainerStatuses {
+			if running := pod.Status.ContainerStatuses[i].State.Running; running != nil {
+				if since == nil || running.StartedAt.After(*since) {
+					since = &running.StartedAt.Time
+				}
+			}
+		}
+		return since
+	case EligibilityContainersReadyPrefix:
+		if status := findContainerStatus(pod, e.container); status != nil && status.State.Running != nil {
+			return &status.State.Running.StartedAt.Time
+		}
+		return nil
+	default:
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				return &condition.LastTransitionTime.Time
+			}
+		}
+		return nil
+	}
+}
+
+// notEligibleSince returns when the pod stopped being eligible (for zen-lead.io/failover-min-delay),
+// or nil if the pod is eligible or the transition time is unknown
+func (e podEligibility) notEligibleSince(pod *corev1.Pod) *time.Time {
+	if e.requiredCondition != "" && e.modeEligible(pod) {
+		// Only the required condition is missing
+		condition := findPodCondition(pod, e.requiredCondition)
+		if condition == nil || condition.Status == corev1.ConditionTrue || condition.LastTransitionTime.IsZero() {
+			return nil
+		}
+		return &condition.LastTransitionTime.Time
+	}
+	switch e.mode {
+	case EligibilityRunning:
+		// A pod leaving phase Running has exited or not started - no grace period
+		return nil
+	case EligibilityContainersReadyPrefix:
+		if e.modeEligible(pod) {
+			return nil
+		}
+		// ContainersReady turns False when any container (including the named one) becomes not ready
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.ContainersReady {
+				if condition.Status == corev1.ConditionTrue || condition.LastTransitionTime.IsZero() {
+					return nil
+				}
+				return &condition.LastTransitionTime.Time
+			}
+		}
+		return nil
+	default:
+		return getPodNotReadySince(pod)
+	}
+}
+
+// findContainerStatus returns the status of the named container, or nil if it has none
+func findContainerStatus(pod *corev1.Pod, name string) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == name {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// containerReadinessChanged reports whether any container's readiness changed (pod watch predicate)
+func containerReadinessChanged(oldPod, newPod *corev1.Pod) bool {
+	if len(oldPod.Status.ContainerStatuses) != len(newPod.Status.ContainerStatuses) {
+		return true
+	}
+	for i := range newPod.Status.ContainerStatuses {
+		oldStatus := findContainerStatus(oldPod, newPod.Status.ContainerStatuses[i].Name)
+		if oldStatus == nil || oldStatus.Ready != newPod.Status.ContainerStatuses[i].Ready {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newSidecarPod builds a Running pod with an app and a sidecar container
+func newSidecarPod(name string, age time.Duration, podReady, appReady, sidecarReady bool) *corev1.Pod {
+	pod := newReadyPod(name, age)
+	if !podReady {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "app", Ready: appReady, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-age))}}},
+		{Name: "sidecar", Ready: sidecarReady, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-age))}}},
+	}
+	return &pod
+}
+
+func TestPodEligibility_IsEligible(t *testing.T) {
+	ready := newSidecarPod("ready", time.Hour, true, true, true)
+	sidecarNotReady := newSidecarPod("sidecar-not-ready", time.Hour, false, true, false)
+	runningOnly := newSidecarPod("running-only", time.Hour, false, false, false)
+	pending := newSidecarPod("pending", time.Hour, false, false, false)
+	pending.Status.Phase = corev1.PodPending
+
+	tests := []struct {
+		name        string
+		value       string
+		pod         *corev1.Pod
+		expected    bool
+		expectError bool
+	}{
+		{name: "default ready", value: "", pod: ready, expected: true},
+		{name: "ready rejects sidecar not ready", value: EligibilityReady, pod: sidecarNotReady, expected: false},
+		{name: "containers-ready ignores sidecar", value: "containers-ready:app", pod: sidecarNotReady, expected: true},
+		{name: "containers-ready requires named container", value: "containers-ready:app", pod: runningOnly, expected: false},
+		{name: "containers-ready unknown container", value: "containers-ready:db", pod: ready, expected: false},
+		{name: "running accepts NotReady pod", value: EligibilityRunning, pod: runningOnly, expected: true},
+		{name: "running rejects pending pod", value: EligibilityRunning, pod: pending, expected: false},
+		{name: "missing container name falls back to ready", value: "containers-ready:", pod: sidecarNotReady, expected: false, expectError: true},
+		{name: "unknown mode falls back to ready", value: "started", pod: runningOnly, expected: false, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-service",
+				Namespace:   "default",
+				Annotations: map[string]string{AnnotationEligibilityService: tt.value},
+			}}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+			eligibility := r.getPodEligibility(svc)
+			if got := eligibility.isEligible(tt.pod); got != tt.expected {
+				t.Errorf("isEligible() = %v, expected %v", got, tt.expected)
+			}
+			if gotEvent := len(eventRecorder.Events) > 0; gotEvent != tt.expectError {
+				t.Errorf("InvalidEligibility event = %v, expected %v", gotEvent, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_EligibilityRunning(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationEligibilityService: EligibilityRunning,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	// Bootstrap: no pod is Ready until one is routed as primary
+	pod := newSidecarPod("pod-a", time.Hour, false, false, false)
+
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, pod).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(50),
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	slice := &discoveryv1.EndpointSlice{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, slice); err != nil {
+		t.Fatalf("failed to get EndpointSlice: %v", err)
+	}
+	if len(slice.Endpoints) != 1 || slice.Endpoints[0].TargetRef == nil || slice.Endpoints[0].TargetRef.Name != "pod-a" {
+		t.Fatalf("expected pod-a as leader endpoint, got %+v", slice.Endpoints)
+	}
+	if ready := slice.Endpoints[0].Conditions.Ready; ready == nil || !*ready {
+		t.Error("expected leader endpoint to be ready under running eligibility")
+	}
+}
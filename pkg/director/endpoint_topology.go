@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AnnotationTopologyModeService is the Kubernetes topology-aware routing annotation ("Auto" enables it)
+	AnnotationTopologyModeService = "service.kubernetes.io/topology-mode"
+	// AnnotationTopologyAwareHintsService is the deprecated predecessor of service.kubernetes.io/topology-mode
+	AnnotationTopologyAwareHintsService = "service.kubernetes.io/topology-aware-hints"
+)
+
+// endpointTopology holds the per-endpoint topology of a managed Service's EndpointSlices
+type endpointTopology struct {
+	// zones maps node names to their topology.kubernetes.io/zone label
+	zones map[string]string
+	// hints publishes zone hints (the Service uses trafficDistribution or topology-aware routing)
+	hints bool
+}
+
+// usesTopologyRouting reports whether the Service asks data planes for topology-aware routing, either with
+// spec.trafficDistribution or the topology-mode (or legacy topology-aware-hints) annotation
+func usesTopologyRouting(svc *corev1.Service) bool {
+	if svc.Spec.TrafficDistribution != nil && *svc.Spec.TrafficDistribution != "" {
+		return true
+	}
+	for _, key := range []string{AnnotationTopologyModeService, AnnotationTopologyAwareHintsService} {
+		if mode := strings.TrimSpace(svc.Annotations[key]); mode != "" && !strings.EqualFold(mode, "disabled") {
+			return true
+		}
+	}
+	return false
+}
+
+// getEndpointTopology resolves the zones of the nodes the pods run on (cached client). Nodes that cannot be
+// fetched or carry no zone label leave the endpoint zone unset, as the Kubernetes EndpointSlice controller does.
+// Without --enable-node-awareness no zones are known, so endpoints carry neither zone nor hints.
+func (r *ServiceDirectorReconciler) getEndpointTopology(ctx context.Context, svc *corev1.Service, pods []*corev1.Pod, logger *sdklog.Logger) endpointTopology {
+	topology := endpointTopology{zones: make(map[string]string, len(pods)), hints: usesTopologyRouting(svc)}
+	if !r.nodeAwareness {
+		return topology
+	}
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		if _, seen := topology.zones[nodeName]; seen {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			logger.Debug("Failed to get node for endpoint zone",
+				sdklog.String("pod", pod.Name),
+				sdklog.String("node", nodeName),
+				sdklog.String("error", err.Error()))
+			topology.zones[nodeName] = ""
+			continue
+		}
+		topology.zones[nodeName] = node.Labels[corev1.LabelTopologyZone]
+	}
+	return topology
+}
+
+// mirrorTopologyRouting copies the source Service's trafficDistribution and topology-aware routing
+// annotations onto a managed Service, so meshes and proxies treat leader traffic like the source's
+func mirrorTopologyRouting(svc, target *corev1.Service) {
+	target.Spec.TrafficDistribution = nil
+	if svc.Spec.TrafficDistribution != nil {
+		trafficDistribution := *svc.Spec.TrafficDistribution
+		target.Spec.TrafficDistribution = &trafficDistribution
+	}
+	for _, key := range []string{AnnotationTopologyModeService, AnnotationTopologyAwareHintsService} {
+		if val, ok := svc.Annotations[key]; ok {
+			if target.Annotations == nil {
+				target.Annotations = make(map[string]string)
+			}
+			target.Annotations[key] = val
+		} else {
+			delete(target.Annotations, key)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUsesTopologyRouting(t *testing.T) {
+	preferClose := corev1.ServiceTrafficDistributionPreferClose
+	tests := []struct {
+		name     string
+		svc      *corev1.Service
+		expected bool
+	}{
+		{name: "none", svc: &corev1.Service{}, expected: false},
+		{name: "trafficDistribution", svc: &corev1.Service{Spec: corev1.ServiceSpec{TrafficDistribution: &preferClose}}, expected: true},
+		{
+			name:     "topology mode auto",
+			svc:      &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationTopologyModeService: "Auto"}}},
+			expected: true,
+		},
+		{
+			name:     "topology mode disabled",
+			svc:      &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationTopologyModeService: "Disabled"}}},
+			expected: false,
+		},
+		{
+			name:     "legacy hints annotation",
+			svc:      &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationTopologyAwareHintsService: "auto"}}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := usesTopologyRouting(tt.svc); got != tt.expected {
+				t.Errorf("usesTopologyRouting() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_EndpointTopology(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	preferClose := corev1.ServiceTrafficDistributionPreferClose
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:      "true",
+				AnnotationTopologyModeService: "Auto",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:            map[string]string{"app": "my-app"},
+			Ports:               []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+			TrafficDistribution: &preferClose,
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "node-a",
+		Labels: map[string]string{corev1.LabelTopologyZone: "us-east-1a"},
+	}}
+	pod := newActivePod("db-0", "10.0.0.1", time.Hour, 8080)
+	pod.Spec.NodeName = "node-a"
+	pod.Spec.Hostname = "db-0"
+	pod.Spec.Subdomain = "db"
+
+	r := &ServiceDirectorReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, node, pod).Build(),
+		Scheme:        scheme,
+		Recorder:      record.NewFakeRecorder(100),
+		Metrics:       metrics.NewRecorder(),
+		nodeAwareness: true,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	leaderKey := types.NamespacedName{Name: "my-service-leader", Namespace: "default"}
+	reconcileLeaderEndpoint := func() (*corev1.Service, discoveryv1.Endpoint) {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		leaderService := &corev1.Service{}
+		if err := r.Get(context.Background(), leaderKey, leaderService); err != nil {
+			t.Fatalf("failed to get leader service: %v", err)
+		}
+		slice := &discoveryv1.EndpointSlice{}
+		if err := r.Get(context.Background(), leaderKey, slice); err != nil {
+			t.Fatalf("failed to get EndpointSlice: %v", err)
+		}
+		if len(slice.Endpoints) != 1 {
+			t.Fatalf("expected 1 endpoint, got %d", len(slice.Endpoints))
+		}
+		return leaderService, slice.Endpoints[0]
+	}
+
+	leaderService, endpoint := reconcileLeaderEndpoint()
+	if endpoint.Zone == nil || *endpoint.Zone != "us-east-1a" {
+		t.Errorf("endpoint Zone = %v, expected us-east-1a", endpoint.Zone)
+	}
+	if endpoint.Hostname == nil || *endpoint.Hostname != "db-0" {
+		t.Errorf("endpoint Hostname = %v, expected db-0", endpoint.Hostname)
+	}
+	if endpoint.Hints == nil || len(endpoint.Hints.ForZones) != 1 || endpoint.Hints.ForZones[0].Name != "us-east-1a" {
+		t.Errorf("endpoint Hints = %+v, expected zone us-east-1a", endpoint.Hints)
+	}
+	if leaderService.Spec.TrafficDistribution == nil || *leaderService.Spec.TrafficDistribution != preferClose {
+		t.Errorf("leader TrafficDistribution = %v, expected %s", leaderService.Spec.TrafficDistribution, preferClose)
+	}
+	if got := leaderService.Annotations[AnnotationTopologyModeService]; got != "Auto" {
+		t.Errorf("leader %s = %q, expected Auto", AnnotationTopologyModeService, got)
+	}
+
+	// Topology routing turned off on the source - mirrored settings and hints go away, the zone stays
+	current := &corev1.Service{}
+	if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	current.Spec.TrafficDistribution = nil
+	delete(current.Annotations, AnnotationTopologyModeService)
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	leaderService, endpoint = reconcileLeaderEndpoint()
+	if endpoint.Hints != nil {
+		t.Errorf("endpoint Hints = %+v, expected none", endpoint.Hints)
+	}
+	if endpoint.Zone == nil || *endpoint.Zone != "us-east-1a" {
+		t.Errorf("endpoint Zone = %v, expected us-east-1a", endpoint.Zone)
+	}
+	if leaderService.Spec.TrafficDistribution != nil {
+		t.Errorf("leader TrafficDistribution = %v, expected nil", *leaderService.Spec.TrafficDistribution)
+	}
+	if _, ok := leaderService.Annotations[AnnotationTopologyModeService]; ok {
+		t.Errorf("leader %s still set", AnnotationTopologyModeService)
+	}
+}
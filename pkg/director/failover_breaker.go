@@ -0,0 +1,193 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationFailoverMaxChangesService caps leader changes per window (e.g. "3"); when exceeded the
+	// circuit breaker suppresses further changes until the window allows one again. Unset or 0 = no limit.
+	AnnotationFailoverMaxChangesService = "zen-lead.io/failover-max-changes"
+	// AnnotationFailoverWindowService is the window for zen-lead.io/failover-max-changes (default 5m)
+	AnnotationFailoverWindowService = "zen-lead.io/failover-window"
+	// AnnotationFailoverSuppressModeService selects what happens while the breaker is open:
+	// "hold" (default) keeps the last-known leader, "empty" leaves the leader Service without endpoints
+	AnnotationFailoverSuppressModeService = "zen-lead.io/failover-suppress-mode"
+
+	defaultFailoverWindow = 5 * time.Minute
+
+	// Failover suppress modes
+	FailoverSuppressHold  = "hold"
+	FailoverSuppressEmpty = "empty"
+)
+
+// failoverRateLimit is the parsed zen-lead.io/failover-max-changes configuration
+type failoverRateLimit struct {
+	maxChanges int
+	window     time.Duration
+	mode       string
+}
+
+// getFailoverRateLimit returns the Service's failover rate limit, or nil when none is configured.
+// Invalid settings emit a Warning event and disable the breaker (leader changes are never blocked by a typo).
+func (r *ServiceDirectorReconciler) getFailoverRateLimit(svc *corev1.Service) *failoverRateLimit {
+	if svc.Annotations == nil {
+		return nil
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationFailoverMaxChangesService])
+	if val == "" {
+		return nil
+	}
+	invalid := func(reason string) *failoverRateLimit {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidFailoverRateLimit",
+			fmt.Sprintf("%s: failover rate limiting disabled", reason))
+		return nil
+	}
+
+	maxChanges, err := strconv.Atoi(val)
+	if err != nil || maxChanges < 0 {
+		return invalid(fmt.Sprintf("Invalid %s %q (expected a non-negative integer)", AnnotationFailoverMaxChangesService, val))
+	}
+	if maxChanges == 0 {
+		return nil
+	}
+	limit := &failoverRateLimit{maxChanges: maxChanges, window: defaultFailoverWindow, mode: FailoverSuppressHold}
+	if val := strings.TrimSpace(svc.Annotations[AnnotationFailoverWindowService]); val != "" {
+		window, err := time.ParseDuration(val)
+		if err != nil || window <= 0 {
+			return invalid(fmt.Sprintf("Invalid %s %q", AnnotationFailoverWindowService, val))
+		}
+		limit.window = window
+	}
+	if val := strings.TrimSpace(svc.Annotations[AnnotationFailoverSuppressModeService]); val != "" {
+		if val != FailoverSuppressHold && val != FailoverSuppressEmpty {
+			return invalid(fmt.Sprintf("Invalid %s %q (expected %q or %q)", AnnotationFailoverSuppressModeService, val, FailoverSuppressHold, FailoverSuppressEmpty))
+		}
+		limit.mode = val
+	}
+	return limit
+}
+
+// recentLeaderChanges returns the Service's leader changes within the window, dropping older ones
+func (r *ServiceDirectorReconciler) recentLeaderChanges(serviceKey string, now time.Time, window time.Duration) []time.Time {
+	r.leaderChangesMu.Lock()
+	defer r.leaderChangesMu.Unlock()
+	changes := r.leaderChanges[serviceKey]
+	i := 0
+	for i < len(changes) && now.Sub(changes[i]) >= window {
+		i++
+	}
+	changes = changes[i:]
+	if len(changes) == 0 {
+		delete(r.leaderChanges, serviceKey)
+		return nil
+	}
+	r.leaderChanges[serviceKey] = changes
+	return append([]time.Time(nil), changes...)
+}
+
+// recordLeaderChange remembers a leader change for the failover rate limit
+func (r *ServiceDirectorReconciler) recordLeaderChange(serviceKey string, at time.Time) {
+	r.leaderChangesMu.Lock()
+	defer r.leaderChangesMu.Unlock()
+	if r.leaderChanges == nil {
+		r.leaderChanges = make(map[string][]time.Time)
+	}
+	r.leaderChanges[serviceKey] = append(r.leaderChanges[serviceKey], at)
+}
+
+// forgetLeaderChanges drops the leader change history of a Service
+func (r *ServiceDirectorReconciler) forgetLeaderChanges(serviceKey string) {
+	r.leaderChangesMu.Lock()
+	defer r.leaderChangesMu.Unlock()
+	delete(r.leaderChanges, serviceKey)
+}
+
+// applyFailoverCircuitBreaker rate-limits leader changes (zen-lead.io/failover-max-changes). It returns the
+// leader to publish, whether the selected leader was suppressed, and when to reconcile again so the
+// deferred change happens as soon as the window allows. Every leader change counts (including to or from
+// no leader); exempt changes (operator pin, planned switchover) are counted but never suppressed.
+// History is kept in controller memory: a controller restart closes the breaker.
+func (r *ServiceDirectorReconciler) applyFailoverCircuitBreaker(svc *corev1.Service, previousLeader, leaderPod *corev1.Pod, pods []corev1.Pod, exempt bool, logger *sdklog.Logger) (*corev1.Pod, bool, time.Duration) {
+	serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+	limit := r.getFailoverRateLimit(svc)
+	if limit == nil {
+		r.forgetLeaderChanges(serviceKey)
+		return leaderPod, false, 0
+	}
+
+	podUID := func(pod *corev1.Pod) string {
+		if pod == nil {
+			return ""
+		}
+		return string(pod.UID)
+	}
+	now := time.Now()
+	changes := r.recentLeaderChanges(serviceKey, now, limit.window)
+	open := len(changes) >= limit.maxChanges
+	if r.Metrics != nil {
+		r.Metrics.RecordFailoverCircuitOpen(svc.Namespace, svc.Name, open)
+	}
+	if podUID(previousLeader) == podUID(leaderPod) {
+		return leaderPod, false, 0
+	}
+	if !open || exempt {
+		r.recordLeaderChange(serviceKey, now)
+		return leaderPod, false, 0
+	}
+
+	// Circuit open - keep the last-known leader (if it still exists) or go empty
+	var held *corev1.Pod
+	if limit.mode == FailoverSuppressHold && previousLeader != nil {
+		for i := range pods {
+			if pods[i].UID == previousLeader.UID && pods[i].Status.PodIP != "" {
+				held = &pods[i]
+				break
+			}
+		}
+	}
+	resetIn := changes[len(changes)-limit.maxChanges].Add(limit.window).Sub(now)
+
+	wanted, kept := "none", "leaving the leader Service without endpoints"
+	if leaderPod != nil {
+		wanted = leaderPod.Name
+	}
+	if held != nil {
+		kept = fmt.Sprintf("holding leader %s", held.Name)
+	}
+	logger.Info("Leader change suppressed by failover circuit breaker",
+		sdklog.Operation("failover"),
+		sdklog.String("wanted", wanted),
+		sdklog.String("mode", limit.mode),
+		sdklog.Int("changes", len(changes)),
+		sdklog.Duration("resetIn", resetIn))
+	r.Recorder.Event(svc, corev1.EventTypeWarning, "FailoverSuppressed",
+		fmt.Sprintf("Leader changed %d times within %s (%s=%d); %s instead of switching to %s for %s",
+			len(changes), limit.window, AnnotationFailoverMaxChangesService, limit.maxChanges, kept, wanted, resetIn.Round(time.Second)))
+	if r.Metrics != nil {
+		r.Metrics.RecordFailoverSuppressed(svc.Namespace, svc.Name, limit.mode)
+	}
+	return held, true, resetIn
+}
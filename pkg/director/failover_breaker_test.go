@@ -0,0 +1,210 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_GetFailoverRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *failoverRateLimit
+		expectEvent bool
+	}{
+		{name: "unset"},
+		{name: "zero disables", annotations: map[string]string{AnnotationFailoverMaxChangesService: "0"}},
+		{
+			name:        "defaults",
+			annotations: map[string]string{AnnotationFailoverMaxChangesService: "3"},
+			expected:    &failoverRateLimit{maxChanges: 3, window: defaultFailoverWindow, mode: FailoverSuppressHold},
+		},
+		{
+			name: "custom window and mode",
+			annotations: map[string]string{
+				AnnotationFailoverMaxChangesService:   "2",
+				AnnotationFailoverWindowService:       "10m",
+				AnnotationFailoverSuppressModeService: "empty",
+			},
+			expected: &failoverRateLimit{maxChanges: 2, window: 10 * time.Minute, mode: FailoverSuppressEmpty},
+		},
+		{name: "invalid max", annotations: map[string]string{AnnotationFailoverMaxChangesService: "many"}, expectEvent: true},
+		{
+			name: "invalid window",
+			annotations: map[string]string{
+				AnnotationFailoverMaxChangesService: "2",
+				AnnotationFailoverWindowService:     "-1m",
+			},
+			expectEvent: true,
+		},
+		{
+			name: "invalid mode",
+			annotations: map[string]string{
+				AnnotationFailoverMaxChangesService:   "2",
+				AnnotationFailoverSuppressModeService: "freeze",
+			},
+			expectEvent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: tt.annotations}}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+
+			got := r.getFailoverRateLimit(svc)
+			if (got == nil) != (tt.expected == nil) || (got != nil && *got != *tt.expected) {
+				t.Errorf("getFailoverRateLimit() = %+v, expected %+v", got, tt.expected)
+			}
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "InvalidFailoverRateLimit") {
+					gotEvent = true
+				}
+			}
+			if gotEvent != tt.expectEvent {
+				t.Errorf("InvalidFailoverRateLimit event = %v, expected %v", gotEvent, tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_FailoverCircuitBreaker(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name            string
+		mode            string
+		expectedLeaders string
+	}{
+		{name: "hold keeps the last-known leader", mode: FailoverSuppressHold, expectedLeaders: "pod-b"},
+		{name: "empty withdraws the leader", mode: FailoverSuppressEmpty, expectedLeaders: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationEnabledService:              "true",
+						AnnotationFailoverMaxChangesService:   "2",
+						AnnotationFailoverWindowService:       "1h",
+						AnnotationFailoverSuppressModeService: tt.mode,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{"app": "my-app"},
+					Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+				},
+			}
+			podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+			podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+			eventRecorder := record.NewFakeRecorder(100)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+			setReady := func(pod *corev1.Pod, status corev1.ConditionStatus) {
+				t.Helper()
+				current := &corev1.Pod{}
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), current); err != nil {
+					t.Fatalf("failed to get pod: %v", err)
+				}
+				current.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+				if err := r.Status().Update(context.Background(), current); err != nil {
+					t.Fatalf("failed to update pod status: %v", err)
+				}
+			}
+			reconcile := func() ctrl.Result {
+				t.Helper()
+				result, err := r.Reconcile(context.Background(), req)
+				if err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+				return result
+			}
+
+			// Two leader changes fit the limit: none -> pod-a -> pod-b
+			reconcile()
+			setReady(podA, corev1.ConditionFalse)
+			reconcile()
+			if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+				t.Fatalf("leader endpoints = %v, expected [pod-b]", got)
+			}
+
+			// Third change within the window is suppressed until the first change leaves the window
+			for len(eventRecorder.Events) > 0 {
+				<-eventRecorder.Events
+			}
+			setReady(podA, corev1.ConditionTrue)
+			setReady(podB, corev1.ConditionFalse)
+			result := reconcile()
+			if got := activeEndpointNames(t, r); strings.Join(got, ",") != tt.expectedLeaders {
+				t.Fatalf("leader endpoints while suppressed = %v, expected [%s]", got, tt.expectedLeaders)
+			}
+			if result.RequeueAfter <= 59*time.Minute || result.RequeueAfter > time.Hour {
+				t.Errorf("RequeueAfter = %v, expected the breaker reset time", result.RequeueAfter)
+			}
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "FailoverSuppressed") {
+					gotEvent = true
+				}
+			}
+			if !gotEvent {
+				t.Error("expected FailoverSuppressed event")
+			}
+
+			// Window elapsed - the deferred change goes through
+			key := "default/my-service"
+			r.leaderChangesMu.Lock()
+			for i := range r.leaderChanges[key] {
+				r.leaderChanges[key][i] = r.leaderChanges[key][i].Add(-time.Hour)
+			}
+			r.leaderChangesMu.Unlock()
+			reconcile()
+			if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+				t.Fatalf("leader endpoints after reset = %v, expected [pod-a]", got)
+			}
+		})
+	}
+}
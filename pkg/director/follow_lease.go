@@ -0,0 +1,186 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// AnnotationFollowLeaseService names a coordination.k8s.io Lease in the Service namespace (e.g. one
+	// held via client-go leader election). The pod holding it becomes leader: HolderIdentity must be the
+	// pod name or "<pod-name>-<pod-uid>", as in pkg/client.
+	AnnotationFollowLeaseService = "zen-lead.io/follow-lease"
+
+	// serviceFollowLeaseIndex indexes Services by followed Lease name for Lease -> Service mapping
+	serviceFollowLeaseIndex = "metadata.annotations.follow-lease"
+)
+
+// SetFollowLease enables zen-lead.io/follow-lease. Off by default: following a Lease needs a cluster-wide
+// Lease informer, and every Lease renewal in the cluster reaches it (see docs/PERFORMANCE_TUNING.md).
+func (r *ServiceDirectorReconciler) SetFollowLease(enabled bool) {
+	r.followLease = enabled
+}
+
+// getFollowLease returns the followed Lease name ("" when unset)
+func getFollowLease(svc *corev1.Service) string {
+	if svc.Annotations == nil {
+		return ""
+	}
+	return strings.TrimSpace(svc.Annotations[AnnotationFollowLeaseService])
+}
+
+// leaseExpiry returns when the Lease expires (renew time + lease duration) and whether it has a live holder
+func leaseExpiry(lease *coordinationv1.Lease) (time.Time, bool) {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || spec.LeaseDurationSeconds == nil {
+		return time.Time{}, false
+	}
+	renewed := spec.RenewTime
+	if renewed == nil {
+		renewed = spec.AcquireTime
+	}
+	if renewed == nil {
+		return time.Time{}, false
+	}
+	return renewed.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second), true
+}
+
+// isLeaseHeld reports whether the Lease has a holder and has not expired at now
+func isLeaseHeld(lease *coordinationv1.Lease, now time.Time) bool {
+	expiry, ok := leaseExpiry(lease)
+	return ok && now.Before(expiry)
+}
+
+// isLeaseHolder reports whether the holder identity names the pod (pod name or "<pod-name>-<pod-uid>")
+func isLeaseHolder(pod *corev1.Pod, holderIdentity string) bool {
+	return holderIdentity == pod.Name || holderIdentity == fmt.Sprintf("%s-%s", pod.Name, pod.UID)
+}
+
+// selectLeaderByLease returns the eligible candidate holding the followed Lease and how long until the
+// Lease expires (0 when there is no live holder). Expired, missing or unowned Leases mean no leader.
+func (r *ServiceDirectorReconciler) selectLeaderByLease(ctx context.Context, svc *corev1.Service, pods, candidates []corev1.Pod, logger *sdklog.Logger) (*corev1.Pod, time.Duration) {
+	leaseName := getFollowLease(svc)
+	noLeader := func(reason string) (*corev1.Pod, time.Duration) {
+		logger.Info("No leader from followed Lease", sdklog.Operation("follow_lease"),
+			sdklog.String("lease", leaseName), sdklog.String("reason", reason))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "NoLeaseHolder",
+			fmt.Sprintf("Lease %s: %s. Leader Service %s will have no endpoints.", leaseName, reason, r.getLeaderServiceName(svc)))
+		return nil, 0
+	}
+	if !r.followLease {
+		// Falling back to zen-lead's own selection could publish a pod that does not hold the Lease
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "FollowLeaseDisabled",
+			fmt.Sprintf("%s is set but the controller runs without --enable-follow-lease. Leader Service %s will have no endpoints.",
+				AnnotationFollowLeaseService, r.getLeaderServiceName(svc)))
+		return nil, 0
+	}
+
+	lease := &coordinationv1.Lease{}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: leaseName}, lease)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_lease"); err != nil {
+		return noLeader(fmt.Sprintf("cannot read Lease: %v", err))
+	}
+
+	now := time.Now()
+	if !isLeaseHeld(lease, now) {
+		return noLeader("Lease has no holder or has expired")
+	}
+	holder := *lease.Spec.HolderIdentity
+	expiry, _ := leaseExpiry(lease)
+
+	for i := range candidates {
+		if isLeaseHolder(&candidates[i], holder) {
+			logger.Debug("Leader selected by Lease", sdklog.String("pod", candidates[i].Name), sdklog.String("lease", leaseName))
+			return &candidates[i], expiry.Sub(now)
+		}
+	}
+	for i := range pods {
+		if isLeaseHolder(&pods[i], holder) {
+			return noLeader(fmt.Sprintf("holder %s is not eligible", pods[i].Name))
+		}
+	}
+	return noLeader(fmt.Sprintf("holder %q is not a pod selected by the Service", holder))
+}
+
+// indexServiceFollowLease is the field indexer for serviceFollowLeaseIndex. Services managed by zen-lead
+// carry a copy of the source annotations and are skipped.
+func indexServiceFollowLease(obj client.Object) []string {
+	svc, ok := obj.(*corev1.Service)
+	if !ok || svc.Labels[LabelManagedBy] == LabelManagedByValue {
+		return nil
+	}
+	if lease := getFollowLease(svc); lease != "" {
+		return []string{lease}
+	}
+	return nil
+}
+
+// mapLeaseToService maps Lease changes to reconciles of the Services following that Lease
+func (r *ServiceDirectorReconciler) mapLeaseToService(ctx context.Context, obj client.Object) []reconcile.Request {
+	svcList := &corev1.ServiceList{}
+	if err := r.List(ctx, svcList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{serviceFollowLeaseIndex: obj.GetName()}); err != nil {
+		packageLogger.WithContext(ctx).Debug("Failed to list services following lease",
+			sdklog.String("lease", obj.GetName()),
+			sdklog.String("error", err.Error()))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(svcList.Items))
+	for i := range svcList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: svcList.Items[i].Name, Namespace: svcList.Items[i].Namespace},
+		})
+	}
+	return requests
+}
+
+// leasePredicate reacts to holder changes and to a Lease becoming held again. Plain renewals are
+// ignored: expiry is handled by requeueing the following Services at the Lease expiry time.
+var leasePredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool { return true },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldLease, okOld := e.ObjectOld.(*coordinationv1.Lease)
+		newLease, okNew := e.ObjectNew.(*coordinationv1.Lease)
+		if !okOld || !okNew {
+			return false
+		}
+		holder := func(lease *coordinationv1.Lease) string {
+			if lease.Spec.HolderIdentity == nil {
+				return ""
+			}
+			return *lease.Spec.HolderIdentity
+		}
+		now := time.Now()
+		return holder(oldLease) != holder(newLease) || isLeaseHeld(oldLease, now) != isLeaseHeld(newLease, now)
+	},
+	DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+	GenericFunc: func(e event.GenericEvent) bool { return false },
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// newLease returns a Lease held by holder, renewed renewedAgo ago with a 15s duration
+func newLease(name, holder string, renewedAgo time.Duration) *coordinationv1.Lease {
+	duration := int32(15)
+	renewTime := metav1.NewMicroTime(time.Now().Add(-renewedAgo))
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renewTime,
+		},
+	}
+}
+
+func newLeaseTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+	_ = coordinationv1.AddToScheme(scheme)
+	return scheme
+}
+
+func TestServiceDirectorReconciler_SelectLeaderByLease(t *testing.T) {
+	scheme := newLeaseTestScheme()
+	notReady := newReadyPod("pod-old", time.Hour)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	tests := []struct {
+		name        string
+		lease       *coordinationv1.Lease
+		pods        []corev1.Pod
+		disabled    bool
+		expectedPod string
+		expectEvent string
+	}{
+		{
+			name:        "holder by pod name",
+			lease:       newLease("my-lock", "pod-new", time.Second),
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-new",
+		},
+		{
+			name:        "holder by pod name and UID",
+			lease:       newLease("my-lock", "pod-new-uid-pod-new", time.Second),
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-new",
+		},
+		{
+			name:        "expired lease means no leader",
+			lease:       newLease("my-lock", "pod-new", time.Minute),
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectEvent: "NoLeaseHolder",
+		},
+		{
+			name:        "missing lease",
+			lease:       newLease("other-lock", "pod-new", time.Second),
+			pods:        []corev1.Pod{newReadyPod("pod-new", time.Minute)},
+			expectEvent: "NoLeaseHolder",
+		},
+		{
+			name:        "holder not eligible",
+			lease:       newLease("my-lock", "pod-old", time.Second),
+			pods:        []corev1.Pod{notReady, newReadyPod("pod-new", time.Minute)},
+			expectEvent: "is not eligible",
+		},
+		{
+			name:        "holder outside the Service",
+			lease:       newLease("my-lock", "other-app-0", time.Second),
+			pods:        []corev1.Pod{newReadyPod("pod-new", time.Minute)},
+			expectEvent: "is not a pod selected by the Service",
+		},
+		{
+			name:        "follow-lease disabled fails closed",
+			lease:       newLease("my-lock", "pod-new", time.Second),
+			pods:        []corev1.Pod{newReadyPod("pod-new", time.Minute)},
+			disabled:    true,
+			expectEvent: "FollowLeaseDisabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-service",
+				Namespace:   "default",
+				Annotations: map[string]string{AnnotationFollowLeaseService: "my-lock"},
+			}}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:      fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, tt.lease).Build(),
+				Scheme:      scheme,
+				Recorder:    eventRecorder,
+				Metrics:     metrics.NewRecorder(),
+				followLease: !tt.disabled,
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			candidates := r.filterLeaderCandidates(svc, tt.pods, logger)
+			leader, remaining := r.selectLeaderByLease(context.Background(), svc, tt.pods, candidates, logger)
+			if tt.expectedPod == "" {
+				if leader != nil {
+					t.Fatalf("selectLeaderByLease() = %s, expected no leader", leader.Name)
+				}
+			} else {
+				if leader == nil || leader.Name != tt.expectedPod {
+					t.Fatalf("selectLeaderByLease() = %v, expected %s", leader, tt.expectedPod)
+				}
+				if remaining <= 0 || remaining > 15*time.Second {
+					t.Errorf("lease remaining = %v, expected within the lease duration", remaining)
+				}
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_FollowLease(t *testing.T) {
+	scheme := newLeaseTestScheme()
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationFollowLeaseService: "my-lock",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+	lease := newLease("my-lock", "pod-b", 5*time.Second)
+
+	r := &ServiceDirectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(svc, podA, podB, lease).
+			WithIndex(&corev1.Service{}, serviceFollowLeaseIndex, indexServiceFollowLease).
+			Build(),
+		Scheme:      scheme,
+		Recorder:    record.NewFakeRecorder(50),
+		Metrics:     metrics.NewRecorder(),
+		followLease: true,
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints = %v, expected [pod-b]", got)
+	}
+	// Requeued at the Lease expiry (renewed 5s ago, 15s duration)
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 10*time.Second {
+		t.Errorf("RequeueAfter = %v, expected the remaining lease time", result.RequeueAfter)
+	}
+
+	if requests := r.mapLeaseToService(context.Background(), lease); len(requests) != 1 || requests[0].Name != "my-service" {
+		t.Errorf("mapLeaseToService() = %v, expected a single my-service request", requests)
+	}
+
+	// The Lease expires - no leader, even though the former holder is still Ready
+	expired := &coordinationv1.Lease{}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(lease), expired); err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	renewTime := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	expired.Spec.RenewTime = &renewTime
+	if err := r.Update(context.Background(), expired); err != nil {
+		t.Fatalf("failed to update lease: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); len(got) != 0 {
+		t.Fatalf("leader endpoints after expiry = %v, expected none", got)
+	}
+}
+
+func TestLeasePredicate(t *testing.T) {
+	held := newLease("my-lock", "pod-a", time.Second)
+	renewed := newLease("my-lock", "pod-a", 0)
+	moved := newLease("my-lock", "pod-b", 0)
+	expired := newLease("my-lock", "pod-a", time.Minute)
+
+	tests := []struct {
+		name     string
+		old, new *coordinationv1.Lease
+		expected bool
+	}{
+		{name: "renewal ignored", old: held, new: renewed, expected: false},
+		{name: "holder change", old: held, new: moved, expected: true},
+		{name: "reacquired after expiry", old: expired, new: renewed, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leasePredicate.Update(event.UpdateEvent{ObjectOld: tt.old, ObjectNew: tt.new}); got != tt.expected {
+				t.Errorf("leasePredicate.Update() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
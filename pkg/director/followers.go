@@ -0,0 +1,217 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationFollowersService enables the companion <svc>-followers Service ("true")
+	AnnotationFollowersService = "zen-lead.io/followers-service"
+	// FollowersServiceSuffix is appended to the source Service name for the followers Service
+	FollowersServiceSuffix = "-followers"
+)
+
+// isFollowersServiceEnabled reports whether zen-lead.io/followers-service is "true"
+func isFollowersServiceEnabled(svc *corev1.Service) bool {
+	return svc.Annotations != nil && svc.Annotations[AnnotationFollowersService] == "true"
+}
+
+// getFollowersServiceName returns the name of the companion followers Service
+func getFollowersServiceName(svc *corev1.Service) string {
+	return svc.Name + FollowersServiceSuffix
+}
+
+// isFollowersServiceOf reports whether service is the zen-lead followers Service of the named source Service
+func isFollowersServiceOf(service *corev1.Service, sourceName string) bool {
+	return service.Labels[LabelManagedBy] == LabelManagedByValue &&
+		service.Labels[LabelSourceService] == sourceName &&
+		service.Labels[LabelLeaderAlias] != "true"
+}
+
+// selectFollowerPods returns the eligible candidates that are not active (leader), ordered by name
+// so that the followers EndpointSlice only changes when membership changes
+func selectFollowerPods(ranked []corev1.Pod, activePods []*corev1.Pod) []*corev1.Pod {
+	active := make(map[types.UID]struct{}, len(activePods))
+	for _, pod := range activePods {
+		active[pod.UID] = struct{}{}
+	}
+	followers := make([]*corev1.Pod, 0, len(ranked))
+	for i := range ranked {
+		if _, ok := active[ranked[i].UID]; !ok {
+			followers = append(followers, &ranked[i])
+		}
+	}
+	sort.Slice(followers, func(i, j int) bool { return followers[i].Name < followers[j].Name })
+	return followers
+}
+
+// reconcileFollowersService creates, updates or deletes the selector-less <svc>-followers Service
+// and its EndpointSlice. It is called before the leader EndpointSlice is updated, so a newly
+// elected leader is removed from the followers before it starts receiving leader traffic.
+func (r *ServiceDirectorReconciler) reconcileFollowersService(ctx context.Context, svc *corev1.Service, followers []*corev1.Pod, logger *sdklog.Logger) error {
+	followersServiceName := getFollowersServiceName(svc)
+	if !isFollowersServiceEnabled(svc) {
+		return r.deleteFollowersService(ctx, svc.Namespace, svc.Name, logger)
+	}
+
+	// Service ports follow zen-lead.io/ports-mode; targetPort is informational for selector-less Services
+	servicePorts, err := selectServicePorts(svc)
+	if err != nil {
+		// Invalid port configuration is reported by the leader Service - fail closed here too
+		servicePorts = nil
+		followers = nil
+	}
+	servicePorts = followerServicePorts(servicePorts)
+
+	// Resolve EndpointSlice ports against the first follower; followers resolving named ports
+	// differently cannot share the slice and are excluded (fail-closed per pod)
+	endpointPorts := []corev1.ServicePort{}
+	if len(followers) > 0 {
+		resolved, err := r.resolveServicePorts(svc, followers[0])
+		if err != nil {
+			followers = nil
+		} else {
+			endpointPorts = resolved
+			filtered := followers[:1]
+			for _, pod := range followers[1:] {
+				if mismatch := r.namedPortMismatch(servicePorts, resolved, pod); mismatch != "" {
+					logger.Debug("Excluding follower with mismatched named port",
+						sdklog.String("pod", pod.Name),
+						sdklog.String("port", mismatch))
+					r.Recorder.Event(svc, corev1.EventTypeWarning, "FollowerPortMismatch",
+						fmt.Sprintf("Pod %s excluded from %s: named port %s does not resolve to the same port as pod %s",
+							pod.Name, followersServiceName, mismatch, followers[0].Name))
+					continue
+				}
+				filtered = append(filtered, pod)
+			}
+			followers = filtered
+		}
+	}
+
+	followersService := &corev1.Service{}
+	followersServiceKey := types.NamespacedName{Name: followersServiceName, Namespace: svc.Namespace}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Get(ctx, followersServiceKey, followersService)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_followers_service"); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get followers service %s/%s: %w", svc.Namespace, followersServiceName, err)
+		}
+		// Filter GitOps labels/annotations to prevent ownership conflicts
+		followersLabels := filterGitOpsLabels(svc.Labels)
+		followersLabels[LabelManagedBy] = LabelManagedByValue
+		followersLabels[LabelSourceService] = svc.Name
+
+		followersService = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        followersServiceName,
+				Namespace:   svc.Namespace,
+				Labels:      followersLabels,
+				Annotations: filterGitOpsAnnotations(svc.Annotations),
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "Service",
+						Name:       svc.Name,
+						UID:        svc.UID,
+						Controller: func() *bool { b := true; return &b }(),
+					},
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: nil, // No selector - we manage endpoints manually
+				Ports:    servicePorts,
+				Type:     corev1.ServiceTypeClusterIP,
+			},
+		}
+		r.mirrorIPFamilies(svc, followersService)
+		mirrorTopologyRouting(svc, followersService)
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return r.Create(ctx, followersService)
+		}, r.Metrics, svc.Namespace, svc.Name, "create_followers_service"); err != nil {
+			return fmt.Errorf("failed to create followers service %s/%s: %w", svc.Namespace, followersServiceName, err)
+		}
+		logger.Info("Created selector-less followers service", sdklog.Operation("create_service"), sdklog.String("service", followersServiceName))
+		r.Recorder.Event(svc, corev1.EventTypeNormal, "FollowersServiceCreated",
+			fmt.Sprintf("Created followers service %s", followersServiceName))
+	} else {
+		if !isFollowersServiceOf(followersService, svc.Name) {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "FollowersServiceConflict",
+				fmt.Sprintf("Service %s already exists and is not the zen-lead followers Service of %s; followers Service skipped", followersServiceName, svc.Name))
+			return nil
+		}
+		originalService := followersService.DeepCopy()
+		followersService.Spec.Selector = nil
+		followersService.Spec.Ports = servicePorts
+		r.mirrorIPFamilies(svc, followersService)
+		mirrorTopologyRouting(svc, followersService)
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return r.Patch(ctx, followersService, client.MergeFrom(originalService))
+		}, r.Metrics, svc.Namespace, svc.Name, "patch_followers_service"); err != nil {
+			return fmt.Errorf("failed to patch followers service %s/%s: %w", svc.Namespace, followersServiceName, err)
+		}
+	}
+
+	if err := r.reconcileEndpointSlice(ctx, svc, followersServiceName, followers, endpointPorts, nil, logger); err != nil {
+		return fmt.Errorf("failed to reconcile followers endpoint slice: %w", err)
+	}
+	return nil
+}
+
+// followerServicePorts copies ports for the ClusterIP followers Service (NodePorts belong to the source)
+func followerServicePorts(ports []corev1.ServicePort) []corev1.ServicePort {
+	out := make([]corev1.ServicePort, len(ports))
+	for i := range ports {
+		out[i] = *ports[i].DeepCopy()
+		out[i].NodePort = 0
+	}
+	return out
+}
+
+// deleteFollowersService deletes the followers Service of the source Service if it exists
+// (GC removes its EndpointSlice)
+func (r *ServiceDirectorReconciler) deleteFollowersService(ctx context.Context, namespace, source string, logger *sdklog.Logger) error {
+	name := source + FollowersServiceSuffix
+	followersService := &corev1.Service{}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, followersService)
+	}, r.Metrics, namespace, name, "get_followers_service_cleanup"); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// Only delete the followers Service this source created
+	if !isFollowersServiceOf(followersService, source) {
+		return nil
+	}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return client.IgnoreNotFound(r.Delete(ctx, followersService))
+	}, r.Metrics, namespace, name, "delete_followers_service"); err != nil {
+		return fmt.Errorf("failed to delete followers service %s/%s: %w", namespace, name, err)
+	}
+	logger.Info("Deleted followers service", sdklog.Operation("delete_service"), sdklog.String("service", name))
+	return nil
+}
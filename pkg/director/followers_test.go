@@ -0,0 +1,243 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSelectFollowerPods(t *testing.T) {
+	ranked := []corev1.Pod{newReadyPod("pod-c", time.Hour), newReadyPod("pod-a", 3*time.Hour), newReadyPod("pod-b", 2*time.Hour)}
+
+	followers := selectFollowerPods(ranked, []*corev1.Pod{&ranked[1]})
+	names := make([]string, 0, len(followers))
+	for _, pod := range followers {
+		names = append(names, pod.Name)
+	}
+	if strings.Join(names, ",") != "pod-b,pod-c" {
+		t.Errorf("selectFollowerPods() = %v, expected [pod-b pod-c]", names)
+	}
+}
+
+// followerEndpointNames returns the pod names in the followers EndpointSlice, in order
+func followerEndpointNames(t *testing.T, r *ServiceDirectorReconciler) []string {
+	t.Helper()
+	slice := &discoveryv1.EndpointSlice{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service" + FollowersServiceSuffix, Namespace: "default"}, slice); err != nil {
+		t.Fatalf("failed to get followers EndpointSlice: %v", err)
+	}
+	names := make([]string, 0, len(slice.Endpoints))
+	for _, endpoint := range slice.Endpoints {
+		if endpoint.TargetRef != nil {
+			names = append(names, endpoint.TargetRef.Name)
+		}
+	}
+	return names
+}
+
+func TestServiceDirectorReconciler_Reconcile_FollowersService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:   "true",
+				AnnotationFollowersService: "true",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Type:     corev1.ServiceTypeNodePort,
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), NodePort: 30080, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 3*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", 2*time.Hour, 8080)
+	podC := newActivePod("pod-c", "10.0.0.3", time.Hour, 8080)
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB, podC).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		for len(eventRecorder.Events) > 0 {
+			<-eventRecorder.Events
+		}
+	}
+
+	// Followers contain every eligible pod except the leader
+	reconcile()
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+		t.Fatalf("leader endpoints = %v, expected [pod-a]", got)
+	}
+	if got := followerEndpointNames(t, r); strings.Join(got, ",") != "pod-b,pod-c" {
+		t.Fatalf("follower endpoints = %v, expected [pod-b pod-c]", got)
+	}
+
+	followersSvc := &corev1.Service{}
+	followersKey := types.NamespacedName{Name: "my-service" + FollowersServiceSuffix, Namespace: "default"}
+	if err := r.Get(ctx, followersKey, followersSvc); err != nil {
+		t.Fatalf("failed to get followers service: %v", err)
+	}
+	if followersSvc.Spec.Selector != nil {
+		t.Errorf("followers service selector = %v, expected nil", followersSvc.Spec.Selector)
+	}
+	if followersSvc.Spec.Type != corev1.ServiceTypeClusterIP || followersSvc.Spec.Ports[0].NodePort != 0 {
+		t.Errorf("followers service type = %s nodePort = %d, expected ClusterIP without nodePort",
+			followersSvc.Spec.Type, followersSvc.Spec.Ports[0].NodePort)
+	}
+
+	// Leader goes away - the new leader leaves the followers
+	if err := r.Delete(ctx, podA); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+	reconcile()
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints after failover = %v, expected [pod-b]", got)
+	}
+	if got := followerEndpointNames(t, r); strings.Join(got, ",") != "pod-c" {
+		t.Fatalf("follower endpoints after failover = %v, expected [pod-c]", got)
+	}
+
+	// Opting out removes the followers Service
+	if err := r.Get(ctx, req.NamespacedName, svc); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	delete(svc.Annotations, AnnotationFollowersService)
+	if err := r.Update(ctx, svc); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	reconcile()
+	if err := r.Get(ctx, followersKey, followersSvc); !apierrors.IsNotFound(err) {
+		t.Errorf("expected followers service to be deleted, got err = %v", err)
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_FollowersServiceConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:   "true",
+				AnnotationFollowersService: "true",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	// my-service-followers belongs to someone else and must be left alone
+	unmanaged := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service" + FollowersServiceSuffix, Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "other"}},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, unmanaged, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	reconcile := func() []string {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		var events []string
+		for len(eventRecorder.Events) > 0 {
+			events = append(events, <-eventRecorder.Events)
+		}
+		return events
+	}
+
+	events := reconcile()
+	conflict := false
+	for _, event := range events {
+		if strings.Contains(event, "FollowersServiceConflict") {
+			conflict = true
+		}
+	}
+	if !conflict {
+		t.Errorf("expected a FollowersServiceConflict event, got %v", events)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+		t.Errorf("leader endpoints = %v, expected [pod-a]", got)
+	}
+	followersKey := types.NamespacedName{Name: unmanaged.Name, Namespace: "default"}
+	followersSvc := &corev1.Service{}
+	if err := r.Get(ctx, followersKey, followersSvc); err != nil {
+		t.Fatalf("failed to get %s: %v", unmanaged.Name, err)
+	}
+	if followersSvc.Labels[LabelManagedBy] != "" || followersSvc.Spec.Selector["app"] != "other" {
+		t.Errorf("unmanaged %s was modified: %+v", unmanaged.Name, followersSvc)
+	}
+	if err := r.Get(ctx, followersKey, &discoveryv1.EndpointSlice{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no followers EndpointSlice, got err = %v", err)
+	}
+
+	// Opting out does not delete the unmanaged Service
+	if err := r.Get(ctx, req.NamespacedName, svc); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	delete(svc.Annotations, AnnotationFollowersService)
+	if err := r.Update(ctx, svc); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	reconcile()
+	if err := r.Get(ctx, followersKey, followersSvc); err != nil {
+		t.Errorf("unmanaged %s was deleted: %v", unmanaged.Name, err)
+	}
+}
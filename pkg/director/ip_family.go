@@ -0,0 +1,144 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// serviceAddressTypes returns the EndpointSlice address types for a Service, primary family first.
+// They follow the source Service's IP families (one slice per family on dual-stack); a Service without
+// IP families (e.g. created before the cluster reported them) falls back to the leader pod's primary IP.
+func serviceAddressTypes(svc *corev1.Service, leaderPod *corev1.Pod) []discoveryv1.AddressType {
+	if len(svc.Spec.IPFamilies) == 0 {
+		return []discoveryv1.AddressType{podAddressType(leaderPod)}
+	}
+	addressTypes := make([]discoveryv1.AddressType, 0, len(svc.Spec.IPFamilies))
+	for _, family := range svc.Spec.IPFamilies {
+		addressType := discoveryv1.AddressTypeIPv4
+		if family == corev1.IPv6Protocol {
+			addressType = discoveryv1.AddressTypeIPv6
+		}
+		addressTypes = append(addressTypes, addressType)
+	}
+	return addressTypes
+}
+
+// endpointSliceNameForFamily returns the EndpointSlice name for an address type. The primary family keeps
+// the Service name (the slice zen-lead reads leader state from); the secondary family gets a suffix.
+func endpointSliceNameForFamily(serviceName string, addressType discoveryv1.AddressType, primary bool) string {
+	if primary {
+		return serviceName
+	}
+	return serviceName + "-" + strings.ToLower(string(addressType))
+}
+
+// podIPForAddressType returns the pod's IP of the given family from status.podIPs (status.podIP when
+// podIPs is not populated), or "" when the pod has no address of that family
+func podIPForAddressType(pod *corev1.Pod, addressType discoveryv1.AddressType) string {
+	if pod == nil {
+		return ""
+	}
+	ips := make([]string, 0, len(pod.Status.PodIPs)+1)
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, podIP.IP)
+	}
+	if len(ips) == 0 {
+		ips = append(ips, pod.Status.PodIP)
+	}
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			continue
+		}
+		if (parsed.To4() == nil) == (addressType == discoveryv1.AddressTypeIPv6) {
+			return ip
+		}
+	}
+	return ""
+}
+
+// mirrorIPFamilies copies the source Service's IP family policy and families onto a managed Service.
+// The primary family of an existing Service is immutable: on a mismatch the families are left alone and an
+// IPFamilyMismatch Warning event asks for the managed Service to be recreated.
+func (r *ServiceDirectorReconciler) mirrorIPFamilies(svc, target *corev1.Service) {
+	if len(svc.Spec.IPFamilies) == 0 {
+		return
+	}
+	if len(target.Spec.IPFamilies) > 0 && target.Spec.IPFamilies[0] != svc.Spec.IPFamilies[0] {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "IPFamilyMismatch",
+			fmt.Sprintf("%s has primary IP family %s but %s has %s; delete %s to recreate it with matching IP families",
+				target.Name, target.Spec.IPFamilies[0], svc.Name, svc.Spec.IPFamilies[0], target.Name))
+		return
+	}
+	target.Spec.IPFamilies = append([]corev1.IPFamily(nil), svc.Spec.IPFamilies...)
+	if svc.Spec.IPFamilyPolicy != nil {
+		policy := *svc.Spec.IPFamilyPolicy
+		target.Spec.IPFamilyPolicy = &policy
+	}
+	// Downgrade to single-stack releases the secondary cluster IP
+	if len(target.Spec.ClusterIPs) > len(target.Spec.IPFamilies) {
+		target.Spec.ClusterIPs = target.Spec.ClusterIPs[:len(target.Spec.IPFamilies)]
+	}
+}
+
+// listEndpointSlices returns the zen-lead managed EndpointSlices of a managed Service (one per IP family)
+func (r *ServiceDirectorReconciler) listEndpointSlices(ctx context.Context, svc *corev1.Service, serviceName, operation string) ([]discoveryv1.EndpointSlice, error) {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.List(ctx, sliceList, client.InNamespace(svc.Namespace), client.MatchingLabels{
+			discoveryv1.LabelServiceName: serviceName,
+			LabelEndpointSliceManagedBy:  LabelEndpointSliceManagedByValue,
+		})
+	}, r.Metrics, svc.Namespace, svc.Name, operation); err != nil {
+		return nil, fmt.Errorf("failed to list endpoint slices of %s/%s: %w", svc.Namespace, serviceName, err)
+	}
+	return sliceList.Items, nil
+}
+
+// deleteStaleEndpointSlices removes the managed EndpointSlices of an IP family the Service no longer has
+// (e.g. after a dual-stack to single-stack downgrade), so no traffic follows an outdated leader
+func (r *ServiceDirectorReconciler) deleteStaleEndpointSlices(ctx context.Context, svc *corev1.Service, serviceName string, keep map[string]struct{}, logger *sdklog.Logger) error {
+	endpointSlices, err := r.listEndpointSlices(ctx, svc, serviceName, "list_endpointslices_stale")
+	if err != nil {
+		return err
+	}
+	for i := range endpointSlices {
+		if _, ok := keep[endpointSlices[i].Name]; ok {
+			continue
+		}
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return client.IgnoreNotFound(r.Delete(ctx, &endpointSlices[i]))
+		}, r.Metrics, svc.Namespace, svc.Name, "delete_endpointslice_stale"); err != nil {
+			return fmt.Errorf("failed to delete stale endpoint slice %s/%s: %w", endpointSlices[i].Namespace, endpointSlices[i].Name, err)
+		}
+		logger.Info("Deleted endpoint slice of removed IP family",
+			sdklog.Operation("delete_endpointslice"),
+			sdklog.String("endpointslice", endpointSlices[i].Name),
+			sdklog.String("addressType", string(endpointSlices[i].AddressType)))
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPodIPForAddressType(t *testing.T) {
+	dualStack := &corev1.Pod{Status: corev1.PodStatus{
+		PodIP:  "fd00::1",
+		PodIPs: []corev1.PodIP{{IP: "fd00::1"}, {IP: "10.0.0.1"}},
+	}}
+	legacy := &corev1.Pod{Status: corev1.PodStatus{PodIP: "10.0.0.2"}}
+
+	tests := []struct {
+		name        string
+		pod         *corev1.Pod
+		addressType discoveryv1.AddressType
+		expected    string
+	}{
+		{name: "dual-stack IPv6", pod: dualStack, addressType: discoveryv1.AddressTypeIPv6, expected: "fd00::1"},
+		{name: "dual-stack IPv4", pod: dualStack, addressType: discoveryv1.AddressTypeIPv4, expected: "10.0.0.1"},
+		{name: "podIP fallback", pod: legacy, addressType: discoveryv1.AddressTypeIPv4, expected: "10.0.0.2"},
+		{name: "missing family", pod: legacy, addressType: discoveryv1.AddressTypeIPv6, expected: ""},
+		{name: "nil pod", pod: nil, addressType: discoveryv1.AddressTypeIPv4, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podIPForAddressType(tt.pod, tt.addressType); got != tt.expected {
+				t.Errorf("podIPForAddressType() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_DualStack(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	preferDualStack := corev1.IPFamilyPolicyPreferDualStack
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationEnabledService: "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:       map[string]string{"app": "my-app"},
+			Ports:          []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			IPFamilyPolicy: &preferDualStack,
+		},
+	}
+	pod := newActivePod("pod-a", "fd00::1", time.Hour, 8080)
+	pod.Status.PodIPs = []corev1.PodIP{{IP: "fd00::1"}, {IP: "10.0.0.1"}}
+
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, pod).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	leaderService := &corev1.Service{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, leaderService); err != nil {
+		t.Fatalf("failed to get leader service: %v", err)
+	}
+	if !reflect.DeepEqual(leaderService.Spec.IPFamilies, svc.Spec.IPFamilies) {
+		t.Errorf("leader IPFamilies = %v, expected %v", leaderService.Spec.IPFamilies, svc.Spec.IPFamilies)
+	}
+	if leaderService.Spec.IPFamilyPolicy == nil || *leaderService.Spec.IPFamilyPolicy != preferDualStack {
+		t.Errorf("leader IPFamilyPolicy = %v, expected %s", leaderService.Spec.IPFamilyPolicy, preferDualStack)
+	}
+
+	for _, tt := range []struct {
+		slice       string
+		addressType discoveryv1.AddressType
+		address     string
+	}{
+		{slice: "my-service-leader", addressType: discoveryv1.AddressTypeIPv6, address: "fd00::1"},
+		{slice: "my-service-leader-ipv4", addressType: discoveryv1.AddressTypeIPv4, address: "10.0.0.1"},
+	} {
+		slice := &discoveryv1.EndpointSlice{}
+		if err := r.Get(context.Background(), types.NamespacedName{Name: tt.slice, Namespace: "default"}, slice); err != nil {
+			t.Fatalf("failed to get EndpointSlice %s: %v", tt.slice, err)
+		}
+		if slice.AddressType != tt.addressType {
+			t.Errorf("%s AddressType = %s, expected %s", tt.slice, slice.AddressType, tt.addressType)
+		}
+		if len(slice.Endpoints) != 1 || !reflect.DeepEqual(slice.Endpoints[0].Addresses, []string{tt.address}) {
+			t.Errorf("%s endpoints = %+v, expected address %s", tt.slice, slice.Endpoints, tt.address)
+		}
+	}
+
+	// Downgrade to single-stack - the IPv4 slice goes away
+	current := &corev1.Service{}
+	if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	singleStack := corev1.IPFamilyPolicySingleStack
+	current.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+	current.Spec.IPFamilyPolicy = &singleStack
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader-ipv4", Namespace: "default"}, &discoveryv1.EndpointSlice{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected IPv4 EndpointSlice to be deleted, got err = %v", err)
+	}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(leaderService), leaderService); err != nil {
+		t.Fatalf("failed to get leader service: %v", err)
+	}
+	if !reflect.DeepEqual(leaderService.Spec.IPFamilies, []corev1.IPFamily{corev1.IPv6Protocol}) {
+		t.Errorf("leader IPFamilies after downgrade = %v, expected [IPv6]", leaderService.Spec.IPFamilies)
+	}
+}
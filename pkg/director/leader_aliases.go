@@ -0,0 +1,236 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationLeaderServiceAliasesService lists additional selector-less Services that route to the same
+	// leader as the leader Service (comma-separated names, e.g. "db-primary,db-rw")
+	AnnotationLeaderServiceAliasesService = "zen-lead.io/leader-service-aliases"
+	// LabelLeaderAlias marks alias leader Services ("true") so aliases removed from the list can be found
+	LabelLeaderAlias = "zen-lead.io/leader-alias"
+)
+
+// aliasLeaderAnnotations are the leader tracking annotations copied from the leader Service onto aliases
+var aliasLeaderAnnotations = []string{
+	"zen-lead.io/current-leader",
+	AnnotationLeaderPodName,
+	AnnotationLeaderPodUID,
+	AnnotationLeaderLastSwitchTime,
+	AnnotationLeaderEpoch,
+	AnnotationActivePods,
+}
+
+// getLeaderServiceAliases returns the de-duplicated alias names in annotation order. Names that are not
+// valid Service names, or that belong to the source, leader or followers Service, emit an
+// InvalidLeaderServiceAlias Warning event and are skipped.
+func (r *ServiceDirectorReconciler) getLeaderServiceAliases(svc *corev1.Service, leaderServiceName string) []string {
+	reserved := map[string]struct{}{
+		svc.Name:                     {},
+		leaderServiceName:            {},
+		getFollowersServiceName(svc): {},
+	}
+	var aliases []string
+	seen := make(map[string]struct{})
+	for _, alias := range splitCommaList(svc.Annotations[AnnotationLeaderServiceAliasesService]) {
+		if _, ok := seen[alias]; ok {
+			continue
+		}
+		seen[alias] = struct{}{}
+		if errs := validation.IsDNS1035Label(alias); len(errs) > 0 {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidLeaderServiceAlias",
+				fmt.Sprintf("Invalid %s entry %q: %s", AnnotationLeaderServiceAliasesService, alias, strings.Join(errs, "; ")))
+			continue
+		}
+		if _, ok := reserved[alias]; ok {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidLeaderServiceAlias",
+				fmt.Sprintf("Invalid %s entry %q: name is already used by the source, leader or followers service",
+					AnnotationLeaderServiceAliasesService, alias))
+			continue
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
+// isLeaderServiceAliasOf reports whether a Service is a zen-lead alias of the given source Service
+func isLeaderServiceAliasOf(service *corev1.Service, sourceName string) bool {
+	return service.Labels[LabelManagedBy] == LabelManagedByValue &&
+		service.Labels[LabelSourceService] == sourceName &&
+		service.Labels[LabelLeaderAlias] == "true"
+}
+
+// reconcileLeaderServiceAliases creates or updates a selector-less Service and EndpointSlice per alias,
+// pointing at the same active pods as the leader Service, and deletes aliases removed from the list.
+// Aliases are ClusterIP Services (headless if the leader Service mode is headless); NodePort and
+// LoadBalancer exposure stays on the leader Service.
+func (r *ServiceDirectorReconciler) reconcileLeaderServiceAliases(ctx context.Context, svc, leaderService *corev1.Service, activePods []*corev1.Pod, leaderPorts []corev1.ServicePort, logger *sdklog.Logger) error {
+	keep := make(map[string]struct{})
+	for _, alias := range r.getLeaderServiceAliases(svc, leaderService.Name) {
+		owned, err := r.reconcileLeaderServiceAlias(ctx, svc, leaderService, alias, activePods, leaderPorts, logger)
+		if err != nil {
+			return err
+		}
+		if owned {
+			keep[alias] = struct{}{}
+		}
+	}
+	return r.deleteLeaderServiceAliases(ctx, svc.Namespace, svc.Name, keep, logger)
+}
+
+// reconcileLeaderServiceAlias reconciles one alias Service and its EndpointSlice. A Service with the alias
+// name that zen-lead does not manage for this source is left alone (LeaderServiceAliasConflict) and
+// reported as not owned.
+func (r *ServiceDirectorReconciler) reconcileLeaderServiceAlias(ctx context.Context, svc, leaderService *corev1.Service, alias string, activePods []*corev1.Pod, leaderPorts []corev1.ServicePort, logger *sdklog.Logger) (bool, error) {
+	aliasService := &corev1.Service{}
+	aliasKey := types.NamespacedName{Name: alias, Namespace: svc.Namespace}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Get(ctx, aliasKey, aliasService)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_alias_service"); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to get alias service %s/%s: %w", svc.Namespace, alias, err)
+		}
+		// Filter GitOps labels/annotations to prevent ownership conflicts
+		aliasLabels := filterGitOpsLabels(svc.Labels)
+		aliasLabels[LabelManagedBy] = LabelManagedByValue
+		aliasLabels[LabelSourceService] = svc.Name
+		aliasLabels[LabelLeaderAlias] = "true"
+
+		aliasService = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        alias,
+				Namespace:   svc.Namespace,
+				Labels:      aliasLabels,
+				Annotations: filterGitOpsAnnotations(svc.Annotations),
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "Service",
+						Name:       svc.Name,
+						UID:        svc.UID,
+						Controller: func() *bool { b := true; return &b }(),
+					},
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: nil, // No selector - we manage endpoints manually
+				Ports:    followerServicePorts(leaderPorts),
+				Type:     corev1.ServiceTypeClusterIP,
+			},
+		}
+		r.applyLeaderServiceAlias(svc, leaderService, aliasService, false, logger)
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return r.Create(ctx, aliasService)
+		}, r.Metrics, svc.Namespace, svc.Name, "create_alias_service"); err != nil {
+			return false, fmt.Errorf("failed to create alias service %s/%s: %w", svc.Namespace, alias, err)
+		}
+		logger.Info("Created leader alias service", sdklog.Operation("create_service"), sdklog.String("service", alias))
+		r.Recorder.Event(svc, corev1.EventTypeNormal, "LeaderServiceAliasCreated",
+			fmt.Sprintf("Created leader alias service %s", alias))
+	} else {
+		if !isLeaderServiceAliasOf(aliasService, svc.Name) {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "LeaderServiceAliasConflict",
+				fmt.Sprintf("Service %s already exists and is not a zen-lead alias of %s; alias skipped", alias, svc.Name))
+			return false, nil
+		}
+		originalService := aliasService.DeepCopy()
+		aliasService.Spec.Selector = nil
+		aliasService.Spec.Type = corev1.ServiceTypeClusterIP
+		aliasService.Spec.Ports = followerServicePorts(leaderPorts)
+		r.applyLeaderServiceAlias(svc, leaderService, aliasService, true, logger)
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return r.Patch(ctx, aliasService, client.MergeFrom(originalService))
+		}, r.Metrics, svc.Namespace, svc.Name, "patch_alias_service"); err != nil {
+			return false, fmt.Errorf("failed to patch alias service %s/%s: %w", svc.Namespace, alias, err)
+		}
+	}
+
+	if err := r.reconcileEndpointSlice(ctx, svc, alias, activePods, leaderPorts, leaderEpochAnnotations(leaderService), logger); err != nil {
+		return true, fmt.Errorf("failed to reconcile alias endpoint slice %s: %w", alias, err)
+	}
+	return true, nil
+}
+
+// applyLeaderServiceAlias mirrors the leader Service mode, IP families, topology routing, traffic policy
+// and leader tracking annotations onto an alias Service
+func (r *ServiceDirectorReconciler) applyLeaderServiceAlias(svc, leaderService, aliasService *corev1.Service, existing bool, logger *sdklog.Logger) {
+	r.applyLeaderServiceMode(svc, aliasService, existing)
+	r.mirrorIPFamilies(svc, aliasService)
+	mirrorTopologyRouting(svc, aliasService)
+	r.reconcileTrafficPolicy(svc, aliasService, nil, logger)
+
+	if aliasService.Annotations == nil {
+		aliasService.Annotations = make(map[string]string)
+	}
+	for _, key := range aliasLeaderAnnotations {
+		if val, ok := leaderService.Annotations[key]; ok {
+			aliasService.Annotations[key] = val
+		} else {
+			delete(aliasService.Annotations, key)
+		}
+	}
+}
+
+// listLeaderServiceAliases lists the alias Services zen-lead manages for a source Service
+func (r *ServiceDirectorReconciler) listLeaderServiceAliases(ctx context.Context, namespace, sourceName, operation string) ([]corev1.Service, error) {
+	aliasList := &corev1.ServiceList{}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.List(ctx, aliasList, client.InNamespace(namespace), client.MatchingLabels{
+			LabelSourceService: sourceName,
+			LabelManagedBy:     LabelManagedByValue,
+			LabelLeaderAlias:   "true",
+		})
+	}, r.Metrics, namespace, sourceName, operation); err != nil {
+		return nil, fmt.Errorf("failed to list alias services for %s/%s: %w", namespace, sourceName, err)
+	}
+	return aliasList.Items, nil
+}
+
+// deleteLeaderServiceAliases deletes the alias Services of a source Service that are not in keep
+// (GC removes their EndpointSlices). A nil keep deletes all aliases.
+func (r *ServiceDirectorReconciler) deleteLeaderServiceAliases(ctx context.Context, namespace, sourceName string, keep map[string]struct{}, logger *sdklog.Logger) error {
+	aliasServices, err := r.listLeaderServiceAliases(ctx, namespace, sourceName, "list_alias_services")
+	if err != nil {
+		return err
+	}
+	for i := range aliasServices {
+		aliasService := &aliasServices[i]
+		if _, ok := keep[aliasService.Name]; ok {
+			continue
+		}
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return client.IgnoreNotFound(r.Delete(ctx, aliasService))
+		}, r.Metrics, namespace, sourceName, "delete_alias_service"); err != nil {
+			return fmt.Errorf("failed to delete alias service %s/%s: %w", namespace, aliasService.Name, err)
+		}
+		logger.Info("Deleted leader alias service", sdklog.Operation("delete_service"), sdklog.String("service", aliasService.Name))
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_GetLeaderServiceAliases(t *testing.T) {
+	tests := []struct {
+		name          string
+		aliases       string
+		expected      []string
+		invalidEvents int
+	}{
+		{name: "unset", aliases: "", expected: nil},
+		{name: "list", aliases: "db-primary, db-rw,db-writer", expected: []string{"db-primary", "db-rw", "db-writer"}},
+		{name: "duplicates", aliases: "db-rw,db-rw, ,db-primary", expected: []string{"db-rw", "db-primary"}},
+		{name: "invalid names", aliases: "DB_Primary,1db,db-rw", expected: []string{"db-rw"}, invalidEvents: 2},
+		{name: "reserved names", aliases: "my-service,my-service-leader,my-service-followers,db-rw", expected: []string{"db-rw"}, invalidEvents: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-service",
+				Namespace:   "default",
+				Annotations: map[string]string{AnnotationLeaderServiceAliasesService: tt.aliases},
+			}}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+
+			if got := r.getLeaderServiceAliases(svc, "my-service-leader"); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("getLeaderServiceAliases() = %v, expected %v", got, tt.expected)
+			}
+			invalidEvents := 0
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "InvalidLeaderServiceAlias") {
+					invalidEvents++
+				}
+			}
+			if invalidEvents != tt.invalidEvents {
+				t.Errorf("InvalidLeaderServiceAlias events = %d, expected %d", invalidEvents, tt.invalidEvents)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_LeaderServiceAliases(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:              "true",
+				AnnotationLeaderServiceAliasesService: "db-primary,db-rw,db-writer",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeNodePort,
+			Selector: map[string]string{"app": "my-app"},
+			Ports: []corev1.ServicePort{{
+				Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), NodePort: 30080, Protocol: corev1.ProtocolTCP,
+			}},
+		},
+	}
+	// db-writer belongs to someone else and must be left alone
+	unmanaged := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-writer", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "other"}},
+	}
+	pod := newActivePod("db-0", "10.0.0.1", time.Hour, 8080)
+
+	eventRecorder := record.NewFakeRecorder(100)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, unmanaged, pod).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	reconcile := func() []string {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		var events []string
+		for len(eventRecorder.Events) > 0 {
+			events = append(events, <-eventRecorder.Events)
+		}
+		return events
+	}
+	aliasExists := func(name string) bool {
+		t.Helper()
+		err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &corev1.Service{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Fatalf("failed to get service %s: %v", name, err)
+		}
+		return err == nil
+	}
+
+	events := reconcile()
+	conflict := false
+	for _, event := range events {
+		if strings.Contains(event, "LeaderServiceAliasConflict") && strings.Contains(event, "db-writer") {
+			conflict = true
+		}
+	}
+	if !conflict {
+		t.Errorf("expected a LeaderServiceAliasConflict event for db-writer, got %v", events)
+	}
+
+	leaderService := &corev1.Service{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, leaderService); err != nil {
+		t.Fatalf("failed to get leader service: %v", err)
+	}
+	for _, alias := range []string{"db-primary", "db-rw"} {
+		aliasService := &corev1.Service{}
+		if err := r.Get(context.Background(), types.NamespacedName{Name: alias, Namespace: "default"}, aliasService); err != nil {
+			t.Fatalf("failed to get alias service %s: %v", alias, err)
+		}
+		if !isLeaderServiceAliasOf(aliasService, svc.Name) {
+			t.Errorf("alias %s labels = %v", alias, aliasService.Labels)
+		}
+		if aliasService.Spec.Selector != nil || aliasService.Spec.Type != corev1.ServiceTypeClusterIP || aliasService.Spec.Ports[0].NodePort != 0 {
+			t.Errorf("alias %s is not a selector-less ClusterIP Service: %+v", alias, aliasService.Spec)
+		}
+		if got := aliasService.Annotations[AnnotationLeaderPodName]; got != "db-0" {
+			t.Errorf("alias %s %s = %q, expected db-0", alias, AnnotationLeaderPodName, got)
+		}
+		slice := &discoveryv1.EndpointSlice{}
+		if err := r.Get(context.Background(), types.NamespacedName{Name: alias, Namespace: "default"}, slice); err != nil {
+			t.Fatalf("failed to get alias EndpointSlice %s: %v", alias, err)
+		}
+		if slice.Labels[discoveryv1.LabelServiceName] != alias {
+			t.Errorf("alias EndpointSlice %s service label = %q", alias, slice.Labels[discoveryv1.LabelServiceName])
+		}
+		if len(slice.Endpoints) != 1 || slice.Endpoints[0].Addresses[0] != "10.0.0.1" {
+			t.Errorf("alias EndpointSlice %s endpoints = %+v, expected the leader pod", alias, slice.Endpoints)
+		}
+		if slice.Annotations[AnnotationLeaderEpoch] != leaderService.Annotations[AnnotationLeaderEpoch] {
+			t.Errorf("alias EndpointSlice %s epoch = %q, expected %q", alias,
+				slice.Annotations[AnnotationLeaderEpoch], leaderService.Annotations[AnnotationLeaderEpoch])
+		}
+	}
+	writer := &corev1.Service{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "db-writer", Namespace: "default"}, writer); err != nil {
+		t.Fatalf("failed to get db-writer: %v", err)
+	}
+	if writer.Labels[LabelManagedBy] != "" || writer.Spec.Selector["app"] != "other" {
+		t.Errorf("unmanaged db-writer was modified: %+v", writer)
+	}
+
+	// db-primary removed from the list - its Service is deleted, the others stay
+	current := &corev1.Service{}
+	if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	current.Annotations[AnnotationLeaderServiceAliasesService] = "db-rw,db-writer"
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	reconcile()
+	if aliasExists("db-primary") {
+		t.Error("db-primary still exists after it was removed from the alias list")
+	}
+	if !aliasExists("db-rw") || !aliasExists("db-writer") {
+		t.Error("expected db-rw and db-writer to remain")
+	}
+
+	// zen-lead disabled - remaining aliases are cleaned up, the unmanaged Service is not
+	if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	delete(current.Annotations, AnnotationEnabledService)
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	reconcile()
+	if aliasExists("db-rw") {
+		t.Error("db-rw still exists after zen-lead was disabled")
+	}
+	if !aliasExists("db-writer") {
+		t.Error("unmanaged db-writer was deleted")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// AnnotationLeaderCountService sets how many pods the leader Service routes to (N-active mode, default 1)
+	AnnotationLeaderCountService = "zen-lead.io/leader-count"
+	// AnnotationActivePods is set on leader Service to list all active pod names (leader first)
+	AnnotationActivePods = "zen-lead.io/active-pods"
+	// AnnotationActivePodUIDs is set on leader Service to list all active pod UIDs (same order as active-pods)
+	AnnotationActivePodUIDs = "zen-lead.io/active-pod-uids"
+
+	// maxLeaderCount bounds zen-lead.io/leader-count to what a single EndpointSlice can hold
+	maxLeaderCount = 1000
+)
+
+// getLeaderCount returns zen-lead.io/leader-count (default 1).
+// Invalid values emit a Warning event and fall back to a single leader. So does a count above 1 when
+// an external source decides the leader: it names exactly one pod, and routing writes to the
+// additional pods would bypass it.
+func (r *ServiceDirectorReconciler) getLeaderCount(svc *corev1.Service) int {
+	if svc.Annotations == nil {
+		return 1
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationLeaderCountService])
+	if val == "" {
+		return 1
+	}
+	count, err := strconv.Atoi(val)
+	if err != nil || count < 1 || count > maxLeaderCount {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidLeaderCount",
+			fmt.Sprintf("Invalid %s %q (expected an integer between 1 and %d). Using a single leader.", AnnotationLeaderCountService, val, maxLeaderCount))
+		return 1
+	}
+	if source := externalLeaderSource(svc); count > 1 && source != "" {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "LeaderCountIgnored",
+			fmt.Sprintf("%s %d is not supported with %s (the external source names a single leader). Using a single leader.", AnnotationLeaderCountService, count, source))
+		return 1
+	}
+	return count
+}
+
+// selectActivePods returns the pods the leader Service routes to: the leader first, then up to
+// zen-lead.io/leader-count - 1 additional pods from the ranked candidates.
+// Stickiness applies per slot: additional pods already in the EndpointSlice keep their slot
+// while they remain eligible, so one slot changing does not reshuffle the others.
+func (r *ServiceDirectorReconciler) selectActivePods(ctx context.Context, svc *corev1.Service, ranked []corev1.Pod, leaderPod *corev1.Pod, logger *sdklog.Logger) []*corev1.Pod {
+	if leaderPod == nil {
+		return nil
+	}
+	activePods := []*corev1.Pod{leaderPod}
+	count := r.getLeaderCount(svc)
+	if count == 1 {
+		return activePods
+	}
+
+	eligible := make(map[types.UID]*corev1.Pod, len(ranked))
+	for i := range ranked {
+		eligible[ranked[i].UID] = &ranked[i]
+	}
+	active := map[types.UID]struct{}{leaderPod.UID: {}}
+
+	// Keep additional pods from their existing slots (sticky per slot)
+	if isStickyEnabled(svc) {
+		endpointSlice := &discoveryv1.EndpointSlice{}
+		endpointSliceKey := types.NamespacedName{Name: r.getLeaderServiceName(svc), Namespace: svc.Namespace}
+		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+			return r.Get(ctx, endpointSliceKey, endpointSlice)
+		}, r.Metrics, svc.Namespace, svc.Name, "get_endpointslice_active"); err == nil {
+			for _, endpoint := range endpointSlice.Endpoints {
+				if len(activePods) >= count {
+					break
+				}
+				if endpoint.TargetRef == nil || endpoint.TargetRef.UID == "" {
+					continue
+				}
+				pod, ok := eligible[endpoint.TargetRef.UID]
+				if _, dup := active[endpoint.TargetRef.UID]; !ok || dup {
+					continue
+				}
+				activePods = append(activePods, pod)
+				active[pod.UID] = struct{}{}
+			}
+		}
+	}
+
+	// Fill the remaining slots in selection order
+	for i := range ranked {
+		if len(activePods) >= count {
+			break
+		}
+		if _, dup := active[ranked[i].UID]; dup {
+			continue
+		}
+		activePods = append(activePods, &ranked[i])
+		active[ranked[i].UID] = struct{}{}
+	}
+
+	if len(activePods) < count {
+		logger.Debug("Fewer eligible pods than leader-count",
+			sdklog.Int("leaderCount", count),
+			sdklog.Int("active", len(activePods)))
+	}
+	return activePods
+}
+
+// filterActivePodsByPorts drops additional active pods whose named ports resolve differently from the
+// leader. All endpoints in an EndpointSlice share one port list, so a mismatch fails closed for that pod.
+func (r *ServiceDirectorReconciler) filterActivePodsByPorts(svc *corev1.Service, leaderPorts []corev1.ServicePort, activePods []*corev1.Pod, logger *sdklog.Logger) []*corev1.Pod {
+	if len(activePods) <= 1 {
+		return activePods
+	}
+	sourcePorts, err := selectServicePorts(svc)
+	if err != nil || len(sourcePorts) != len(leaderPorts) {
+		return activePods[:1]
+	}
+
+	filtered := activePods[:1]
+	for _, pod := range activePods[1:] {
+		if mismatch := r.namedPortMismatch(sourcePorts, leaderPorts, pod); mismatch != "" {
+			logger.Info("Excluding active pod with mismatched named port",
+				sdklog.Operation("select_active"),
+				sdklog.String("pod", pod.Name),
+				sdklog.String("port", mismatch))
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "ActivePodPortMismatch",
+				fmt.Sprintf("Pod %s excluded from %s: named port %s does not resolve to the same port as leader %s",
+					pod.Name, r.getLeaderServiceName(svc), mismatch, activePods[0].Name))
+			continue
+		}
+		filtered = append(filtered, pod)
+	}
+	return filtered
+}
+
+// namedPortMismatch returns the first named targetPort that does not resolve on pod to the same port
+// as in resolvedPorts ("" if all match). sourcePorts and resolvedPorts must be index-aligned.
+func (r *ServiceDirectorReconciler) namedPortMismatch(sourcePorts, resolvedPorts []corev1.ServicePort, pod *corev1.Pod) string {
+	for i := range sourcePorts {
+		if sourcePorts[i].TargetPort.Type != intstr.String {
+			continue
+		}
+		portName := sourcePorts[i].TargetPort.StrVal
+		port, err := r.resolveNamedPort(pod, portName)
+		if err != nil || port != resolvedPorts[i].TargetPort.IntVal {
+			return portName
+		}
+	}
+	return ""
+}
+
+// setActivePodAnnotations records all active pods (leader first) on the leader Service annotations
+func setActivePodAnnotations(annotations map[string]string, activePods []*corev1.Pod) {
+	if len(activePods) == 0 {
+		delete(annotations, AnnotationActivePods)
+		delete(annotations, AnnotationActivePodUIDs)
+		return
+	}
+	names := make([]string, 0, len(activePods))
+	uids := make([]string, 0, len(activePods))
+	for _, pod := range activePods {
+		names = append(names, pod.Name)
+		uids = append(uids, string(pod.UID))
+	}
+	annotations[AnnotationActivePods] = strings.Join(names, ",")
+	annotations[AnnotationActivePodUIDs] = strings.Join(uids, ",")
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_GetLeaderCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		source      string
+		expected    int
+		expectEvent bool
+	}{
+		{value: "", expected: 1},
+		{value: "1", expected: 1},
+		{value: "3", expected: 3},
+		{value: "0", expected: 1, expectEvent: true},
+		{value: "-2", expected: 1, expectEvent: true},
+		{value: "two", expected: 1, expectEvent: true},
+		{value: "5000", expected: 1, expectEvent: true},
+		{name: "role-probe", value: "2", source: AnnotationRoleProbeService, expected: 1, expectEvent: true},
+		{name: "leader-label", value: "2", source: AnnotationLeaderLabelService, expected: 1, expectEvent: true},
+		{name: "follow-lease", value: "2", source: AnnotationFollowLeaseService, expected: 1, expectEvent: true},
+		{name: "leader-label single", value: "1", source: AnnotationLeaderLabelService, expected: 1},
+	}
+
+	for _, tt := range tests {
+		name := tt.value
+		if tt.name != "" {
+			name = tt.name
+		}
+		t.Run(name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-service",
+				Namespace:   "default",
+				Annotations: map[string]string{AnnotationLeaderCountService: tt.value},
+			}}
+			if tt.source != "" {
+				svc.Annotations[tt.source] = "role=primary"
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+			if got := r.getLeaderCount(svc); got != tt.expected {
+				t.Errorf("getLeaderCount() = %d, expected %d", got, tt.expected)
+			}
+			if gotEvent := len(eventRecorder.Events) > 0; gotEvent != tt.expectEvent {
+				t.Errorf("leader-count Warning event = %v, expected %v", gotEvent, tt.expectEvent)
+			}
+		})
+	}
+}
+
+// newActivePod builds a Ready pod with a named http port and a distinct IP
+func newActivePod(name, ip string, age time.Duration, httpPort int32) *corev1.Pod {
+	pod := newReadyPod(name, age)
+	pod.Status.PodIP = ip
+	pod.Spec.Containers = []corev1.Container{{
+		Name:  "app",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: httpPort}},
+	}}
+	return &pod
+}
+
+// activeEndpointNames returns the pod names in the leader EndpointSlice, in order
+func activeEndpointNames(t *testing.T, r *ServiceDirectorReconciler) []string {
+	t.Helper()
+	slice := &discoveryv1.EndpointSlice{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, slice); err != nil {
+		t.Fatalf("failed to get EndpointSlice: %v", err)
+	}
+	names := make([]string, 0, len(slice.Endpoints))
+	for _, endpoint := range slice.Endpoints {
+		if endpoint.TargetRef != nil {
+			names = append(names, endpoint.TargetRef.Name)
+		}
+	}
+	return names
+}
+
+func TestServiceDirectorReconciler_Reconcile_LeaderCount(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationLeaderCountService: "2",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 3*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		for len(eventRecorder.Events) > 0 {
+			<-eventRecorder.Events
+		}
+	}
+
+	// Both pods active, leader first
+	reconcile()
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a,pod-b" {
+		t.Fatalf("active endpoints = %v, expected [pod-a pod-b]", got)
+	}
+	leaderSvc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, leaderSvc); err != nil {
+		t.Fatalf("failed to get leader service: %v", err)
+	}
+	if leaderSvc.Annotations[AnnotationActivePods] != "pod-a,pod-b" {
+		t.Errorf("%s = %q, expected pod-a,pod-b", AnnotationActivePods, leaderSvc.Annotations[AnnotationActivePods])
+	}
+	if leaderSvc.Annotations[AnnotationLeaderPodName] != "pod-a" {
+		t.Errorf("%s = %q, expected pod-a", AnnotationLeaderPodName, leaderSvc.Annotations[AnnotationLeaderPodName])
+	}
+
+	// An older pod joins - the occupied slot is sticky and is not reshuffled
+	podC := newActivePod("pod-c", "10.0.0.3", 2*time.Hour, 8080)
+	if err := r.Create(ctx, podC); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+	reconcile()
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a,pod-b" {
+		t.Fatalf("active endpoints after new pod = %v, expected [pod-a pod-b]", got)
+	}
+
+	// The second slot's pod becomes NotReady - only that slot changes
+	podB.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+	if err := r.Status().Update(ctx, podB); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	reconcile()
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a,pod-c" {
+		t.Fatalf("active endpoints after slot failure = %v, expected [pod-a pod-c]", got)
+	}
+}
+
+func TestServiceDirectorReconciler_SelectActivePods_PortMismatch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationLeaderCountService: "3",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	objs := []client.Object{
+		svc,
+		newActivePod("pod-a", "10.0.0.1", 3*time.Hour, 8080),
+		newActivePod("pod-b", "10.0.0.2", 2*time.Hour, 9090), // different named port
+		newActivePod("pod-c", "10.0.0.3", time.Hour, 8080),
+	}
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a,pod-c" {
+		t.Errorf("active endpoints = %v, expected [pod-a pod-c]", got)
+	}
+	gotMismatch := false
+	for len(eventRecorder.Events) > 0 {
+		if strings.Contains(<-eventRecorder.Events, "ActivePodPortMismatch") {
+			gotMismatch = true
+		}
+	}
+	if !gotMismatch {
+		t.Error("expected ActivePodPortMismatch event")
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_LeaderCountWithLeaderLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationLeaderCountService: "2",
+				AnnotationLeaderLabelService: "role=master",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 3*time.Hour, 8080)
+	podA.Labels["role"] = "replica"
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+	podB.Labels["role"] = "master"
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// The labelled pod is the only endpoint - the replica is not routed to as a second active pod
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Errorf("active endpoints = %v, expected [pod-b]", got)
+	}
+	ignored := false
+	for len(eventRecorder.Events) > 0 {
+		if strings.Contains(<-eventRecorder.Events, "LeaderCountIgnored") {
+			ignored = true
+		}
+	}
+	if !ignored {
+		t.Error("expected a LeaderCountIgnored event")
+	}
+}
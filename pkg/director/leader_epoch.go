@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationLeaderEpoch is set on the leader Service and EndpointSlice: a fencing token that increases by
+	// one on every leader change (including to and from no leader). Downstream systems reject writes carrying
+	// an older epoch than the highest they have seen.
+	AnnotationLeaderEpoch = "zen-lead.io/leader-epoch"
+
+	// LeaderEpochConfigMapSuffix names the ConfigMap ("<service>-leader-epoch") in which the controller keeps
+	// the highest epoch handed out for a source Service, so the epoch keeps increasing when the leader Service
+	// or EndpointSlice is deleted and recreated (opt-out and opt-in again, manual deletion, a leader Service
+	// rename). It is owned by the source Service and deleted with it.
+	LeaderEpochConfigMapSuffix = "-leader-epoch"
+
+	// leaderEpochHighWaterKey is the ConfigMap data key holding the high-water mark
+	leaderEpochHighWaterKey = "high-water"
+)
+
+// parseLeaderEpoch returns the stored epoch (0 when unset or invalid)
+func parseLeaderEpoch(annotations map[string]string) uint64 {
+	return parseEpoch(annotations[AnnotationLeaderEpoch])
+}
+
+// parseEpoch parses an epoch value (0 when unset or invalid)
+func parseEpoch(value string) uint64 {
+	epoch, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return epoch
+}
+
+// getLeaderEpochConfigMapName returns the name of the ConfigMap holding the epoch high-water mark
+func getLeaderEpochConfigMapName(svc *corev1.Service) string {
+	return svc.Name + LeaderEpochConfigMapSuffix
+}
+
+// isLeaderEpochConfigMapOf reports whether configMap is the zen-lead epoch ConfigMap of the named source Service
+func isLeaderEpochConfigMapOf(configMap *corev1.ConfigMap, sourceName string) bool {
+	return configMap.Labels[LabelManagedBy] == LabelManagedByValue &&
+		configMap.Labels[LabelSourceService] == sourceName
+}
+
+// nextLeaderEpoch returns the epoch for a new leader. It is derived from the stored values, never from
+// controller memory, so it keeps increasing across controller restarts and failovers; the EndpointSlice
+// value covers a leader Service whose annotation was lost or edited, and the high-water mark in the epoch
+// ConfigMap covers both being deleted. The high-water mark is raised before the epoch is returned, so an
+// epoch is never handed out without being recorded first. ConfigMaps are not cached (see cmd/manager), so
+// the high-water mark is read fresh and its update carries that resourceVersion: a concurrent writer
+// makes it conflict and retry instead of writing a lower value.
+func (r *ServiceDirectorReconciler) nextLeaderEpoch(ctx context.Context, svc *corev1.Service, leaderServiceName string, leaderService *corev1.Service) (string, error) {
+	var epoch uint64
+	if leaderService != nil {
+		epoch = parseLeaderEpoch(leaderService.Annotations)
+	}
+	endpointSlice := &discoveryv1.EndpointSlice{}
+	if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
+		return r.Get(ctx, types.NamespacedName{Name: leaderServiceName, Namespace: svc.Namespace}, endpointSlice)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_endpointslice_epoch"); err == nil {
+		epoch = max(epoch, parseLeaderEpoch(endpointSlice.Annotations))
+	}
+
+	configMapKey := types.NamespacedName{Name: getLeaderEpochConfigMapName(svc), Namespace: svc.Namespace}
+	var next string
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, configMapKey, configMap); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			next = strconv.FormatUint(epoch+1, 10)
+			return r.Create(ctx, newLeaderEpochConfigMap(svc, configMapKey.Name, next))
+		}
+		if !isLeaderEpochConfigMapOf(configMap, svc.Name) {
+			return fmt.Errorf("configmap %s/%s exists and is not managed by zen-lead for service %s", configMapKey.Namespace, configMapKey.Name, svc.Name)
+		}
+		next = strconv.FormatUint(max(epoch, parseEpoch(configMap.Data[leaderEpochHighWaterKey]))+1, 10)
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string, 1)
+		}
+		configMap.Data[leaderEpochHighWaterKey] = next
+		return r.Update(ctx, configMap)
+	}, r.Metrics, svc.Namespace, svc.Name, "update_leader_epoch_high_water"); err != nil {
+		return "", fmt.Errorf("failed to record leader epoch high-water mark in configmap %s/%s: %w", configMapKey.Namespace, configMapKey.Name, err)
+	}
+	return next, nil
+}
+
+// newLeaderEpochConfigMap builds the epoch ConfigMap of a source Service, owned by it
+func newLeaderEpochConfigMap(svc *corev1.Service, name, highWater string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: svc.Namespace,
+			Labels: map[string]string{
+				LabelManagedBy:     LabelManagedByValue,
+				LabelSourceService: svc.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "v1",
+					Kind:       "Service",
+					Name:       svc.Name,
+					UID:        svc.UID,
+					Controller: func() *bool { b := true; return &b }(),
+				},
+			},
+		},
+		Data: map[string]string{leaderEpochHighWaterKey: highWater},
+	}
+}
+
+// leaderEpochAnnotations returns the EndpointSlice annotations carrying the leader Service epoch
+func leaderEpochAnnotations(leaderService *corev1.Service) map[string]string {
+	epoch, ok := leaderService.Annotations[AnnotationLeaderEpoch]
+	if !ok {
+		return nil
+	}
+	return map[string]string{AnnotationLeaderEpoch: epoch}
+}
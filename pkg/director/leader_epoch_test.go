@@ -0,0 +1,227 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseLeaderEpoch(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected uint64
+	}{
+		{value: "", expected: 0},
+		{value: "7", expected: 7},
+		{value: "-1", expected: 0},
+		{value: "seven", expected: 0},
+	}
+	for _, tt := range tests {
+		if got := parseLeaderEpoch(map[string]string{AnnotationLeaderEpoch: tt.value}); got != tt.expected {
+			t.Errorf("parseLeaderEpoch(%q) = %d, expected %d", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_LeaderEpoch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationEnabledService: "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build()
+	newReconciler := func() *ServiceDirectorReconciler {
+		return &ServiceDirectorReconciler{
+			Client:   fakeClient,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(50),
+			Metrics:  metrics.NewRecorder(),
+		}
+	}
+	r := newReconciler()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	leaderKey := types.NamespacedName{Name: "my-service-leader", Namespace: "default"}
+
+	expectEpoch := func(expected string) {
+		t.Helper()
+		leaderSvc := &corev1.Service{}
+		if err := r.Get(context.Background(), leaderKey, leaderSvc); err != nil {
+			t.Fatalf("failed to get leader service: %v", err)
+		}
+		slice := &discoveryv1.EndpointSlice{}
+		if err := r.Get(context.Background(), leaderKey, slice); err != nil {
+			t.Fatalf("failed to get EndpointSlice: %v", err)
+		}
+		if got := leaderSvc.Annotations[AnnotationLeaderEpoch]; got != expected {
+			t.Errorf("leader Service %s = %q, expected %q", AnnotationLeaderEpoch, got, expected)
+		}
+		if got := slice.Annotations[AnnotationLeaderEpoch]; got != expected {
+			t.Errorf("EndpointSlice %s = %q, expected %q", AnnotationLeaderEpoch, got, expected)
+		}
+	}
+	setReady := func(pod *corev1.Pod, status corev1.ConditionStatus) {
+		t.Helper()
+		current := &corev1.Pod{}
+		if err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), current); err != nil {
+			t.Fatalf("failed to get pod: %v", err)
+		}
+		current.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		if err := r.Status().Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to update pod status: %v", err)
+		}
+	}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	// First leader
+	reconcile()
+	expectEpoch("1")
+
+	// Same leader - no bump
+	reconcile()
+	expectEpoch("1")
+
+	// Failover to pod-b
+	setReady(podA, corev1.ConditionFalse)
+	reconcile()
+	expectEpoch("2")
+
+	// Controller restart - the epoch continues from the stored value
+	r = newReconciler()
+	setReady(podA, corev1.ConditionTrue)
+	setReady(podB, corev1.ConditionFalse)
+	reconcile()
+	expectEpoch("3")
+
+	// No leader is a leader change too
+	setReady(podA, corev1.ConditionFalse)
+	reconcile()
+	expectEpoch("4")
+
+	// Opt-out deletes the leader Service and EndpointSlice; opting in again continues from the
+	// high-water mark in the epoch ConfigMap
+	setReady(podA, corev1.ConditionTrue)
+	updateService := func(mutate func(*corev1.Service)) {
+		t.Helper()
+		current := &corev1.Service{}
+		if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+			t.Fatalf("failed to get service: %v", err)
+		}
+		mutate(current)
+		if err := r.Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to update service: %v", err)
+		}
+	}
+	updateService(func(s *corev1.Service) { delete(s.Annotations, AnnotationEnabledService) })
+	reconcile()
+	if err := r.Get(context.Background(), leaderKey, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("leader service not deleted on opt-out: %v", err)
+	}
+	updateService(func(s *corev1.Service) { s.Annotations = map[string]string{AnnotationEnabledService: "true"} })
+	reconcile()
+	expectEpoch("5")
+
+	// Leader Service and EndpointSlice deleted by hand - recreated with the next epoch
+	if err := r.Delete(context.Background(), &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: leaderKey.Name, Namespace: leaderKey.Namespace}}); err != nil {
+		t.Fatalf("failed to delete leader service: %v", err)
+	}
+	if err := r.Delete(context.Background(), &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: leaderKey.Name, Namespace: leaderKey.Namespace}}); err != nil {
+		t.Fatalf("failed to delete EndpointSlice: %v", err)
+	}
+	reconcile()
+	expectEpoch("6")
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader-epoch", Namespace: "default"}, configMap); err != nil {
+		t.Fatalf("failed to get epoch ConfigMap: %v", err)
+	}
+	if got := configMap.Data[leaderEpochHighWaterKey]; got != "6" {
+		t.Errorf("epoch ConfigMap %s = %q, expected \"6\"", leaderEpochHighWaterKey, got)
+	}
+	if len(configMap.OwnerReferences) != 1 || configMap.OwnerReferences[0].Name != svc.Name {
+		t.Errorf("epoch ConfigMap owner references = %v, expected the source Service", configMap.OwnerReferences)
+	}
+	source := &corev1.Service{}
+	if err := r.Get(context.Background(), req.NamespacedName, source); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	for k := range source.Annotations {
+		if strings.Contains(k, "epoch") {
+			t.Errorf("source Service annotated with %s: the controller must not write to it", k)
+		}
+	}
+}
+
+func TestServiceDirectorReconciler_NextLeaderEpoch_ForeignConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default"}}
+	foreign := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service-leader-epoch", Namespace: "default"},
+		Data:       map[string]string{"config": "user data"},
+	}
+	r := &ServiceDirectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, foreign).Build(),
+		Scheme: scheme,
+	}
+
+	if epoch, err := r.nextLeaderEpoch(context.Background(), svc, "my-service-leader", nil); err == nil {
+		t.Fatalf("nextLeaderEpoch() = %q, expected an error for a ConfigMap not managed by zen-lead", epoch)
+	}
+	current := &corev1.ConfigMap{}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(foreign), current); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if len(current.Data) != 1 || current.Data["config"] != "user data" {
+		t.Errorf("foreign ConfigMap modified: %v", current.Data)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AnnotationLeaderLabelService follows a pod label set by an external tool (Patroni, Stolon, Redis
+// Sentinel operators, ...) as the source of leadership truth, e.g. "role=master". The value is a
+// label selector; the single eligible pod matching it becomes leader.
+const AnnotationLeaderLabelService = "zen-lead.io/leader-label"
+
+// hasLeaderLabel reports whether zen-lead.io/leader-label is set
+func hasLeaderLabel(svc *corev1.Service) bool {
+	return svc.Annotations != nil && strings.TrimSpace(svc.Annotations[AnnotationLeaderLabelService]) != ""
+}
+
+// parseLeaderLabel parses zen-lead.io/leader-label as a non-empty label selector
+func parseLeaderLabel(svc *corev1.Service) (labels.Selector, error) {
+	val := strings.TrimSpace(svc.Annotations[AnnotationLeaderLabelService])
+	selector, err := labels.Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", val, err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("label selector %q matches every pod", val)
+	}
+	return selector, nil
+}
+
+// cachedLeaderLabel returns the leader label selector kept in the opted-in Services cache, or nil
+// when zen-lead.io/leader-label is not set or invalid
+func cachedLeaderLabel(svc *corev1.Service) labels.Selector {
+	if !hasLeaderLabel(svc) {
+		return nil
+	}
+	selector, err := parseLeaderLabel(svc)
+	if err != nil {
+		return nil
+	}
+	return selector
+}
+
+// leaderLabelChanged reports whether a pod label change matters to a Service following
+// zen-lead.io/leader-label: the Service selects the pod and the pod's match against the leader
+// label changed. Other label changes (e.g. a rollout hash) do not trigger reconciles. When the
+// namespace is not cached yet, any label change counts.
+func (r *ServiceDirectorReconciler) leaderLabelChanged(oldPod, newPod *corev1.Pod) bool {
+	if maps.Equal(oldPod.Labels, newPod.Labels) {
+		return false
+	}
+	oldLabels, newLabels := labels.Set(oldPod.Labels), labels.Set(newPod.Labels)
+
+	r.cacheMu.RLock()
+	defer r.cacheMu.RUnlock()
+	cachedServices, cached := r.optedInServicesCache[newPod.Namespace]
+	if !cached {
+		return true
+	}
+	for _, cachedSvc := range cachedServices {
+		if cachedSvc.leaderLabel == nil {
+			continue
+		}
+		if !cachedSvc.selector.Matches(oldLabels) && !cachedSvc.selector.Matches(newLabels) {
+			continue
+		}
+		if cachedSvc.leaderLabel.Matches(oldLabels) != cachedSvc.leaderLabel.Matches(newLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectLeaderByLabel returns the single candidate carrying the leader label. Like role probes it never
+// guesses: when zero or several candidates carry the label (or the annotation is invalid), there is no leader.
+// Pods carrying the label but not eligible (e.g. a crashed former primary the tool could not relabel) are ignored.
+func (r *ServiceDirectorReconciler) selectLeaderByLabel(svc *corev1.Service, candidates []corev1.Pod, logger *sdklog.Logger) *corev1.Pod {
+	selector, err := parseLeaderLabel(svc)
+	if err != nil {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidLeaderLabel",
+			fmt.Sprintf("Invalid %s: %v. Leader Service %s will have no endpoints until fixed.",
+				AnnotationLeaderLabelService, err, r.getLeaderServiceName(svc)))
+		return nil
+	}
+
+	var labeled []*corev1.Pod
+	for i := range candidates {
+		if selector.Matches(labels.Set(candidates[i].Labels)) {
+			labeled = append(labeled, &candidates[i])
+		}
+	}
+
+	switch len(labeled) {
+	case 0:
+		logger.Info("No eligible pod carries the leader label", sdklog.Operation("select_leader"),
+			sdklog.String("leaderLabel", selector.String()), sdklog.Int("candidates", len(candidates)))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "NoLabeledLeader",
+			fmt.Sprintf("No eligible pod carries leader label %s. Leader Service %s will have no endpoints.",
+				selector.String(), r.getLeaderServiceName(svc)))
+		return nil
+	case 1:
+		logger.Debug("Leader selected by label", sdklog.String("pod", labeled[0].Name), sdklog.String("leaderLabel", selector.String()))
+		return labeled[0]
+	default:
+		names := make([]string, 0, len(labeled))
+		for _, pod := range labeled {
+			names = append(names, pod.Name)
+		}
+		logger.Info("Several pods carry the leader label", sdklog.Operation("select_leader"),
+			sdklog.String("leaderLabel", selector.String()), sdklog.String("pods", strings.Join(names, ",")))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "MultipleLabeledLeaders",
+			fmt.Sprintf("Pods %s all carry leader label %s. Leader Service %s will have no endpoints until exactly one does.",
+				strings.Join(names, ", "), selector.String(), r.getLeaderServiceName(svc)))
+		return nil
+	}
+}
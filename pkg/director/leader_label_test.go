@@ -0,0 +1,237 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// withRoleLabel returns the pod with the role label set
+func withRoleLabel(pod corev1.Pod, role string) corev1.Pod {
+	pod.Labels = map[string]string{"app": "my-app", "role": role}
+	return pod
+}
+
+func TestServiceDirectorReconciler_SelectLeaderPod_LeaderLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	notReady := newReadyPod("pod-old", time.Hour)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	tests := []struct {
+		name        string
+		leaderLabel string
+		pods        []corev1.Pod
+		expectedPod string
+		expectEvent string
+	}{
+		{
+			name:        "labeled pod wins over strategy order",
+			leaderLabel: "role=master",
+			pods:        []corev1.Pod{withRoleLabel(newReadyPod("pod-old", time.Hour), "replica"), withRoleLabel(newReadyPod("pod-new", time.Minute), "master")},
+			expectedPod: "pod-new",
+		},
+		{
+			name:        "no labeled pod",
+			leaderLabel: "role=master",
+			pods:        []corev1.Pod{withRoleLabel(newReadyPod("pod-old", time.Hour), "replica")},
+			expectEvent: "NoLabeledLeader",
+		},
+		{
+			name:        "several labeled pods",
+			leaderLabel: "role=master",
+			pods:        []corev1.Pod{withRoleLabel(newReadyPod("pod-old", time.Hour), "master"), withRoleLabel(newReadyPod("pod-new", time.Minute), "master")},
+			expectEvent: "MultipleLabeledLeaders",
+		},
+		{
+			name:        "labeled NotReady pod is ignored",
+			leaderLabel: "role=master",
+			pods:        []corev1.Pod{withRoleLabel(notReady, "master"), withRoleLabel(newReadyPod("pod-new", time.Minute), "master")},
+			expectedPod: "pod-new",
+		},
+		{
+			name:        "set-based selector",
+			leaderLabel: "role in (master,primary)",
+			pods:        []corev1.Pod{withRoleLabel(newReadyPod("pod-old", time.Hour), "replica"), withRoleLabel(newReadyPod("pod-new", time.Minute), "primary")},
+			expectedPod: "pod-new",
+		},
+		{
+			name:        "invalid selector fails closed",
+			leaderLabel: "role in (master",
+			pods:        []corev1.Pod{withRoleLabel(newReadyPod("pod-old", time.Hour), "master")},
+			expectEvent: "InvalidLeaderLabel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationEnabledService:     "true",
+						AnnotationLeaderLabelService: tt.leaderLabel,
+					},
+				},
+			}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			leader := r.selectLeaderPod(context.Background(), svc, tt.pods, false, logger)
+			if tt.expectedPod == "" {
+				if leader != nil {
+					t.Fatalf("selectLeaderPod() = %s, expected no leader", leader.Name)
+				}
+			} else if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderPod() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_LeaderLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationLeaderLabelService: "role=master",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "redis", Port: 6379, TargetPort: intstr.FromInt32(6379), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 6379)
+	podA.Labels["role"] = "replica"
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 6379)
+	podB.Labels["role"] = "master"
+
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(50),
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints = %v, expected [pod-b]", got)
+	}
+
+	// The external tool moves the label - zen-lead follows, stickiness does not apply
+	for name, role := range map[string]string{"pod-a": "master", "pod-b": "replica"} {
+		pod := &corev1.Pod{}
+		if err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, pod); err != nil {
+			t.Fatalf("failed to get pod: %v", err)
+		}
+		pod.Labels["role"] = role
+		if err := r.Update(context.Background(), pod); err != nil {
+			t.Fatalf("failed to update pod: %v", err)
+		}
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+		t.Fatalf("leader endpoints after relabel = %v, expected [pod-a]", got)
+	}
+}
+
+func TestServiceDirectorReconciler_LeaderLabelChanged(t *testing.T) {
+	follower := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "db",
+		Namespace:   "default",
+		Annotations: map[string]string{AnnotationEnabledService: "true", AnnotationLeaderLabelService: "role=master"},
+	}}
+	r := &ServiceDirectorReconciler{optedInServicesCache: map[string][]*cachedService{
+		"default": {
+			{name: "db", selector: labels.SelectorFromSet(map[string]string{"app": "db"}), leaderLabel: cachedLeaderLabel(follower)},
+			{name: "web", selector: labels.SelectorFromSet(map[string]string{"app": "web"})},
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		namespace string
+		oldLabels map[string]string
+		newLabels map[string]string
+		expected  bool
+	}{
+		{name: "leader label set", namespace: "default", oldLabels: map[string]string{"app": "db", "role": "replica"}, newLabels: map[string]string{"app": "db", "role": "master"}, expected: true},
+		{name: "leader label removed", namespace: "default", oldLabels: map[string]string{"app": "db", "role": "master"}, newLabels: map[string]string{"app": "db"}, expected: true},
+		{name: "unrelated label on follower pod", namespace: "default", oldLabels: map[string]string{"app": "db", "role": "master"}, newLabels: map[string]string{"app": "db", "role": "master", "hash": "abc"}},
+		{name: "pod of a Service without leader label", namespace: "default", oldLabels: map[string]string{"app": "web"}, newLabels: map[string]string{"app": "web", "role": "master"}},
+		{name: "labels unchanged", namespace: "default", oldLabels: map[string]string{"app": "db"}, newLabels: map[string]string{"app": "db"}},
+		{name: "namespace not cached yet", namespace: "other", oldLabels: map[string]string{"app": "db"}, newLabels: map[string]string{"app": "db", "hash": "abc"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: tt.namespace, Labels: tt.oldLabels}}
+			newPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: tt.namespace, Labels: tt.newLabels}}
+			if got := r.leaderLabelChanged(oldPod, newPod); got != tt.expected {
+				t.Errorf("leaderLabelChanged() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SelectionStrategyEarliestReady selects the Ready pod with the earliest creationTimestamp (default)
+	SelectionStrategyEarliestReady = "earliest-ready"
+	// SelectionStrategyNewest selects the Ready pod with the latest creationTimestamp
+	SelectionStrategyNewest = "newest"
+	// SelectionStrategyLexicalName selects the Ready pod with the lexically smallest name
+	SelectionStrategyLexicalName = "lexical-name"
+	// SelectionStrategyStableHash selects the Ready pod with the highest rendezvous hash for the Service
+	SelectionStrategyStableHash = "stable-hash"
+	// SelectionStrategyRandomSeeded shuffles Ready pods with a per-Service seed
+	SelectionStrategyRandomSeeded = "random-seeded"
+
+	// DefaultSelectionStrategy is used when zen-lead.io/strategy is not set
+	DefaultSelectionStrategy = SelectionStrategyEarliestReady
+
+	// AnnotationStrategySeedService overrides the seed used by the random-seeded strategy
+	AnnotationStrategySeedService = "zen-lead.io/strategy-seed"
+)
+
+// LeaderSelector orders leader candidates for a Service.
+// Implementations receive only eligible (Ready, flap-damped) pods and must sort them
+// in place, most preferred first. Sorting must be deterministic for a given input so
+// that repeated reconciles converge on the same leader.
+type LeaderSelector interface {
+	// Name returns the strategy name matched against the zen-lead.io/strategy annotation
+	Name() string
+	// Sort orders candidates in place, most preferred first
+	Sort(svc *corev1.Service, candidates []corev1.Pod)
+}
+
+// leaderSelectors holds the available leader selection strategies by name
+var (
+	leaderSelectorsMu sync.RWMutex
+	leaderSelectors   = map[string]LeaderSelector{}
+)
+
+func init() {
+	RegisterLeaderSelector(earliestReadySelector{})
+	RegisterLeaderSelector(newestSelector{})
+	RegisterLeaderSelector(lexicalNameSelector{})
+	RegisterLeaderSelector(stableHashSelector{})
+	RegisterLeaderSelector(randomSeededSelector{})
+}
+
+// RegisterLeaderSelector registers a leader selection strategy.
+// Registering a name that already exists replaces the previous strategy.
+func RegisterLeaderSelector(selector LeaderSelector) {
+	leaderSelectorsMu.Lock()
+	defer leaderSelectorsMu.Unlock()
+	leaderSelectors[selector.Name()] = selector
+}
+
+// GetLeaderSelector returns the leader selection strategy registered under name
+func GetLeaderSelector(name string) (LeaderSelector, bool) {
+	leaderSelectorsMu.RLock()
+	defer leaderSelectorsMu.RUnlock()
+	selector, ok := leaderSelectors[name]
+	return selector, ok
+}
+
+// LeaderSelectorNames returns the names of all registered strategies in lexical order
+func LeaderSelectorNames() []string {
+	leaderSelectorsMu.RLock()
+	defer leaderSelectorsMu.RUnlock()
+	names := make([]string, 0, len(leaderSelectors))
+	for name := range leaderSelectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getLeaderSelector resolves the strategy for a Service from the zen-lead.io/strategy annotation.
+// Unknown strategies emit a Warning event and fall back to the default strategy.
+func (r *ServiceDirectorReconciler) getLeaderSelector(svc *corev1.Service) LeaderSelector {
+	name := DefaultSelectionStrategy
+	if svc.Annotations != nil && svc.Annotations[AnnotationStrategyService] != "" {
+		name = svc.Annotations[AnnotationStrategyService]
+	}
+	if selector, ok := GetLeaderSelector(name); ok {
+		r.clearAnnotationWarning(svc, AnnotationStrategyService)
+		return selector
+	}
+	r.recordAnnotationWarning(svc, AnnotationStrategyService, "UnknownStrategy",
+		fmt.Sprintf("Unknown leader selection strategy %q (supported: %v). Falling back to %s.", name, LeaderSelectorNames(), DefaultSelectionStrategy))
+	selector, _ := GetLeaderSelector(DefaultSelectionStrategy)
+	return selector
+}
+
+// earliestReadySelector prefers the oldest pod (creationTimestamp), then lexical name
+type earliestReadySelector struct{}
+
+func (earliestReadySelector) Name() string { return SelectionStrategyEarliestReady }
+
+func (earliestReadySelector) Sort(_ *corev1.Service, candidates []corev1.Pod) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].CreationTimestamp.Equal(&candidates[j].CreationTimestamp) {
+			return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+}
+
+// newestSelector prefers the youngest pod (creationTimestamp), then lexical name
+type newestSelector struct{}
+
+func (newestSelector) Name() string { return SelectionStrategyNewest }
+
+func (newestSelector) Sort(_ *corev1.Service, candidates []corev1.Pod) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].CreationTimestamp.Equal(&candidates[j].CreationTimestamp) {
+			return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+}
+
+// lexicalNameSelector prefers the lexically smallest pod name (e.g. StatefulSet ordinal 0)
+type lexicalNameSelector struct{}
+
+func (lexicalNameSelector) Name() string { return SelectionStrategyLexicalName }
+
+func (lexicalNameSelector) Sort(_ *corev1.Service, candidates []corev1.Pod) {
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+}
+
+// stableHashSelector uses rendezvous (highest random weight) hashing of Service and pod name.
+// Adding or removing a pod only moves leadership if that pod wins or held the top weight.
+type stableHashSelector struct{}
+
+func (stableHashSelector) Name() string { return SelectionStrategyStableHash }
+
+func (stableHashSelector) Sort(svc *corev1.Service, candidates []corev1.Pod) {
+	key := svc.Namespace + "/" + svc.Name
+	weights := make(map[string]uint64, len(candidates))
+	for i := range candidates {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(key + "/" + candidates[i].Name))
+		weights[candidates[i].Name] = h.Sum64()
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		wi, wj := weights[candidates[i].Name], weights[candidates[j].Name]
+		if wi != wj {
+			return wi > wj
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+}
+
+// randomSeededSelector shuffles candidates with a seed derived from the Service.
+// The seed defaults to the Service UID and can be pinned via zen-lead.io/strategy-seed,
+// so the order is random across Services but stable across reconciles.
+type randomSeededSelector struct{}
+
+func (randomSeededSelector) Name() string { return SelectionStrategyRandomSeeded }
+
+func (randomSeededSelector) Sort(svc *corev1.Service, candidates []corev1.Pod) {
+	// Start from a deterministic order so the shuffle only depends on the seed
+	lexicalNameSelector{}.Sort(svc, candidates)
+	seed := randomSeed(svc)
+	rng := rand.New(rand.NewPCG(seed, seed)) //nolint:gosec // not used for security
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+}
+
+// randomSeed returns the seed for the random-seeded strategy
+func randomSeed(svc *corev1.Service) uint64 {
+	if svc.Annotations != nil {
+		if val := svc.Annotations[AnnotationStrategySeedService]; val != "" {
+			if seed, err := strconv.ParseUint(val, 10, 64); err == nil {
+				return seed
+			}
+			// Non-numeric seeds are hashed so any string can be used
+			h := fnv.New64a()
+			_, _ = h.Write([]byte(val))
+			return h.Sum64()
+		}
+	}
+	h := fnv.New64a()
+	if svc.UID != "" {
+		_, _ = h.Write([]byte(svc.UID))
+	} else {
+		_, _ = h.Write([]byte(svc.Namespace + "/" + svc.Name))
+	}
+	return h.Sum64()
+}
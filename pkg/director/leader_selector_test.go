@@ -0,0 +1,243 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newReadyPod builds a Ready pod for selection tests
+func newReadyPod(name string, age time.Duration) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID("uid-" + name),
+			Labels:            map[string]string{"app": "my-app"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+			PodIP: "10.0.0.1",
+		},
+	}
+}
+
+func TestLeaderSelectorRegistry(t *testing.T) {
+	for _, name := range []string{
+		SelectionStrategyEarliestReady,
+		SelectionStrategyNewest,
+		SelectionStrategyLexicalName,
+		SelectionStrategyStableHash,
+		SelectionStrategyRandomSeeded,
+	} {
+		selector, ok := GetLeaderSelector(name)
+		if !ok {
+			t.Fatalf("GetLeaderSelector(%q) not registered", name)
+		}
+		if selector.Name() != name {
+			t.Errorf("GetLeaderSelector(%q).Name() = %q", name, selector.Name())
+		}
+	}
+	if _, ok := GetLeaderSelector("does-not-exist"); ok {
+		t.Error("GetLeaderSelector() returned a selector for an unknown name")
+	}
+}
+
+func TestLeaderSelector_Sort(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", UID: "svc-uid"}}
+
+	tests := []struct {
+		strategy string
+		expected string
+	}{
+		{strategy: SelectionStrategyEarliestReady, expected: "pod-b"},
+		{strategy: SelectionStrategyNewest, expected: "pod-c"},
+		{strategy: SelectionStrategyLexicalName, expected: "pod-a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			pods := []corev1.Pod{
+				newReadyPod("pod-a", 5*time.Minute),
+				newReadyPod("pod-b", 10*time.Minute),
+				newReadyPod("pod-c", 1*time.Minute),
+			}
+			selector, _ := GetLeaderSelector(tt.strategy)
+			selector.Sort(svc, pods)
+			if pods[0].Name != tt.expected {
+				t.Errorf("%s selected %s, expected %s", tt.strategy, pods[0].Name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLeaderSelector_DeterministicStrategies(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", UID: "svc-uid"}}
+
+	for _, strategy := range []string{SelectionStrategyStableHash, SelectionStrategyRandomSeeded} {
+		t.Run(strategy, func(t *testing.T) {
+			selector, _ := GetLeaderSelector(strategy)
+
+			first := []corev1.Pod{newReadyPod("pod-a", time.Minute), newReadyPod("pod-b", time.Minute), newReadyPod("pod-c", time.Minute)}
+			second := []corev1.Pod{newReadyPod("pod-c", time.Minute), newReadyPod("pod-a", time.Minute), newReadyPod("pod-b", time.Minute)}
+			selector.Sort(svc, first)
+			selector.Sort(svc, second)
+
+			for i := range first {
+				if first[i].Name != second[i].Name {
+					t.Fatalf("%s order depends on input order: %s vs %s at %d", strategy, first[i].Name, second[i].Name, i)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderSelector_StableHashMinimalDisruption(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default"}}
+	selector, _ := GetLeaderSelector(SelectionStrategyStableHash)
+
+	pods := []corev1.Pod{newReadyPod("pod-a", time.Minute), newReadyPod("pod-b", time.Minute), newReadyPod("pod-c", time.Minute)}
+	selector.Sort(svc, pods)
+	leader := pods[0].Name
+
+	// Removing a non-leader pod must not move leadership
+	remaining := []corev1.Pod{pods[2], pods[0]}
+	selector.Sort(svc, remaining)
+	if remaining[0].Name != leader {
+		t.Errorf("stable-hash leader moved from %s to %s after removing a follower", leader, remaining[0].Name)
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderPod_Strategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		strategy      string
+		expectedPod   string
+		expectWarning bool
+	}{
+		{name: "default strategy", strategy: "", expectedPod: "pod-old"},
+		{name: "newest strategy", strategy: SelectionStrategyNewest, expectedPod: "pod-new"},
+		{name: "unknown strategy falls back to default", strategy: "fastest", expectedPod: "pod-old", expectWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-service",
+					Namespace:   "default",
+					Annotations: map[string]string{AnnotationEnabledService: "true"},
+				},
+			}
+			if tt.strategy != "" {
+				svc.Annotations[AnnotationStrategyService] = tt.strategy
+			}
+			pods := []corev1.Pod{newReadyPod("pod-new", time.Minute), newReadyPod("pod-old", time.Hour)}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			leader := r.selectLeaderPod(context.Background(), svc, pods, true, logger)
+			if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderPod() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotWarning := false
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "UnknownStrategy") {
+					gotWarning = true
+				}
+			}
+			if gotWarning != tt.expectWarning {
+				t.Errorf("UnknownStrategy event = %v, expected %v", gotWarning, tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_UnknownStrategyWarnsOnce(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:  "true",
+				AnnotationStrategyService: "bogus",
+			},
+		},
+	}
+	eventRecorder := record.NewFakeRecorder(10)
+	r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+
+	countWarnings := func() int {
+		n := 0
+		for len(eventRecorder.Events) > 0 {
+			if strings.Contains(<-eventRecorder.Events, "UnknownStrategy") {
+				n++
+			}
+		}
+		return n
+	}
+
+	r.getLeaderSelector(svc)
+	r.getLeaderSelector(svc)
+	if got := countWarnings(); got != 1 {
+		t.Fatalf("UnknownStrategy events for repeated reconciles = %d, expected 1", got)
+	}
+
+	// Fixing the annotation and breaking it again reports the new misconfiguration
+	svc.Annotations[AnnotationStrategyService] = SelectionStrategyNewest
+	r.getLeaderSelector(svc)
+	svc.Annotations[AnnotationStrategyService] = "bogus"
+	r.getLeaderSelector(svc)
+	if got := countWarnings(); got != 1 {
+		t.Fatalf("UnknownStrategy events after regression = %d, expected 1", got)
+	}
+
+	// Forgetting the service (opt-out) resets the state too
+	r.forgetAnnotationWarnings("default/my-service")
+	r.getLeaderSelector(svc)
+	if got := countWarnings(); got != 1 {
+		t.Fatalf("UnknownStrategy events after forget = %d, expected 1", got)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationLeaderServiceModeService selects the kind of leader Service: "clusterip" (default, a virtual IP
+	// that kube-proxy points at the leader) or "headless" (DNS returns the leader pod IP directly)
+	AnnotationLeaderServiceModeService = "zen-lead.io/leader-service-mode"
+
+	// Leader Service modes
+	LeaderServiceModeClusterIP = "clusterip"
+	LeaderServiceModeHeadless  = "headless"
+)
+
+// getLeaderServiceMode returns the Service's leader Service mode. Invalid values emit a Warning event and
+// fall back to clusterip.
+func (r *ServiceDirectorReconciler) getLeaderServiceMode(svc *corev1.Service) string {
+	val := strings.ToLower(strings.TrimSpace(svc.Annotations[AnnotationLeaderServiceModeService]))
+	switch val {
+	case "", LeaderServiceModeClusterIP:
+		return LeaderServiceModeClusterIP
+	case LeaderServiceModeHeadless:
+		return LeaderServiceModeHeadless
+	}
+	r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidLeaderServiceMode",
+		fmt.Sprintf("Invalid %s %q (expected %q or %q): using %q", AnnotationLeaderServiceModeService, val,
+			LeaderServiceModeClusterIP, LeaderServiceModeHeadless, LeaderServiceModeClusterIP))
+	return LeaderServiceModeClusterIP
+}
+
+// applyLeaderServiceMode makes the leader Service headless (clusterIP None, type ClusterIP, no node ports)
+// in headless mode. clusterIP is immutable, so an existing leader Service of the other mode is kept as it is
+// and a LeaderServiceModeMismatch Warning event asks for it to be recreated.
+func (r *ServiceDirectorReconciler) applyLeaderServiceMode(svc, leaderService *corev1.Service, existing bool) {
+	mode := r.getLeaderServiceMode(svc)
+	headless := mode == LeaderServiceModeHeadless
+	if existing {
+		if isHeadless := leaderService.Spec.ClusterIP == corev1.ClusterIPNone; isHeadless != headless {
+			current := LeaderServiceModeClusterIP
+			if isHeadless {
+				current = LeaderServiceModeHeadless
+			}
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "LeaderServiceModeMismatch",
+				fmt.Sprintf("Leader service %s is %s but %s is %q; clusterIP cannot be changed in place, delete %s to recreate it",
+					leaderService.Name, current, AnnotationLeaderServiceModeService, mode, leaderService.Name))
+			headless = isHeadless
+		}
+	}
+	if !headless {
+		return
+	}
+	leaderService.Spec.Type = corev1.ServiceTypeClusterIP
+	leaderService.Spec.ClusterIP = corev1.ClusterIPNone
+	for i := range leaderService.Spec.Ports {
+		leaderService.Spec.Ports[i].NodePort = 0
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_Reconcile_LeaderServiceMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		sourceType        corev1.ServiceType
+		sourceClusterIP   string
+		mode              string
+		expectedClusterIP string
+		expectEvent       string
+	}{
+		{name: "headless source defaults to ClusterIP", sourceClusterIP: corev1.ClusterIPNone, expectedClusterIP: ""},
+		{name: "headless mode keeps headless source headless", sourceClusterIP: corev1.ClusterIPNone, mode: "headless", expectedClusterIP: corev1.ClusterIPNone},
+		{name: "headless mode on a NodePort source", sourceType: corev1.ServiceTypeNodePort, mode: "Headless", expectedClusterIP: corev1.ClusterIPNone},
+		{name: "invalid mode", sourceClusterIP: corev1.ClusterIPNone, mode: "direct", expectedClusterIP: "", expectEvent: "InvalidLeaderServiceMode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{AnnotationEnabledService: "true"}
+			if tt.mode != "" {
+				annotations[AnnotationLeaderServiceModeService] = tt.mode
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: annotations},
+				Spec: corev1.ServiceSpec{
+					Type:      tt.sourceType,
+					ClusterIP: tt.sourceClusterIP,
+					Selector:  map[string]string{"app": "my-app"},
+					Ports: []corev1.ServicePort{{
+						Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), NodePort: 30080, Protocol: corev1.ProtocolTCP,
+					}},
+				},
+			}
+			pod := newActivePod("db-0", "10.0.0.1", time.Hour, 8080)
+			pod.Spec.Hostname = "db-0"
+			pod.Spec.Subdomain = "db"
+
+			eventRecorder := record.NewFakeRecorder(100)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, pod).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			leaderService := &corev1.Service{}
+			leaderKey := types.NamespacedName{Name: "my-service-leader", Namespace: "default"}
+			if err := r.Get(context.Background(), leaderKey, leaderService); err != nil {
+				t.Fatalf("failed to get leader service: %v", err)
+			}
+			if leaderService.Spec.ClusterIP != tt.expectedClusterIP {
+				t.Errorf("leader ClusterIP = %q, expected %q", leaderService.Spec.ClusterIP, tt.expectedClusterIP)
+			}
+			if tt.expectedClusterIP == corev1.ClusterIPNone {
+				if leaderService.Spec.Type != corev1.ServiceTypeClusterIP || leaderService.Spec.Ports[0].NodePort != 0 {
+					t.Errorf("headless leader service has type %s and nodePort %d", leaderService.Spec.Type, leaderService.Spec.Ports[0].NodePort)
+				}
+				// Per-hostname DNS records come from the endpoint hostname
+				slice := &discoveryv1.EndpointSlice{}
+				if err := r.Get(context.Background(), leaderKey, slice); err != nil {
+					t.Fatalf("failed to get EndpointSlice: %v", err)
+				}
+				if hostname := slice.Endpoints[0].Hostname; hostname == nil || *hostname != "db-0" {
+					t.Errorf("endpoint Hostname = %v, expected db-0", hostname)
+				}
+			}
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				if event := <-eventRecorder.Events; tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_ApplyLeaderServiceMode_Mismatch(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:        "my-service",
+		Namespace:   "default",
+		Annotations: map[string]string{AnnotationLeaderServiceModeService: LeaderServiceModeHeadless},
+	}}
+	leaderService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service-leader", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.96.0.10"},
+	}
+	eventRecorder := record.NewFakeRecorder(10)
+	r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+
+	r.applyLeaderServiceMode(svc, leaderService, true)
+	if leaderService.Spec.ClusterIP != "10.96.0.10" {
+		t.Errorf("ClusterIP = %q, expected the existing cluster IP to be kept", leaderService.Spec.ClusterIP)
+	}
+	if len(eventRecorder.Events) != 1 || !strings.Contains(<-eventRecorder.Events, "LeaderServiceModeMismatch") {
+		t.Error("expected LeaderServiceModeMismatch event")
+	}
+}
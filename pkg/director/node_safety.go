@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AnnotationUnsafeNodePolicyService controls leadership of pods on cordoned, NoExecute-tainted or spot nodes
+	AnnotationUnsafeNodePolicyService = "zen-lead.io/unsafe-node-policy"
+	// AnnotationSpotNodeSelectorService is a label selector identifying spot/preemptible nodes
+	// (e.g. "karpenter.sh/capacity-type=spot")
+	AnnotationSpotNodeSelectorService = "zen-lead.io/spot-node-selector"
+
+	// UnsafeNodePolicyIgnore does not consider node state (default)
+	UnsafeNodePolicyIgnore = "ignore"
+	// UnsafeNodePolicyLastResort selects pods on unsafe nodes only when no other pod is eligible
+	UnsafeNodePolicyLastResort = "last-resort"
+	// UnsafeNodePolicyExclude never selects pods on unsafe nodes
+	UnsafeNodePolicyExclude = "exclude"
+)
+
+// getUnsafeNodePolicy returns the unsafe node policy for a Service.
+// Unknown values emit a Warning event and fall back to ignore.
+func (r *ServiceDirectorReconciler) getUnsafeNodePolicy(svc *corev1.Service) string {
+	if svc.Annotations == nil {
+		return UnsafeNodePolicyIgnore
+	}
+	switch val := strings.TrimSpace(svc.Annotations[AnnotationUnsafeNodePolicyService]); val {
+	case "", UnsafeNodePolicyIgnore:
+		r.clearAnnotationWarning(svc, AnnotationUnsafeNodePolicyService)
+		return UnsafeNodePolicyIgnore
+	case UnsafeNodePolicyLastResort, UnsafeNodePolicyExclude:
+		if r.nodeAwarenessDisabled(svc, AnnotationUnsafeNodePolicyService) {
+			return UnsafeNodePolicyIgnore
+		}
+		r.clearAnnotationWarning(svc, AnnotationUnsafeNodePolicyService)
+		return val
+	default:
+		r.recordAnnotationWarning(svc, AnnotationUnsafeNodePolicyService, "InvalidUnsafeNodePolicy",
+			fmt.Sprintf("Invalid %s %q (supported: %s, %s, %s). Node state is ignored.",
+				AnnotationUnsafeNodePolicyService, val, UnsafeNodePolicyIgnore, UnsafeNodePolicyLastResort, UnsafeNodePolicyExclude))
+		return UnsafeNodePolicyIgnore
+	}
+}
+
+// getSpotNodeSelector parses zen-lead.io/spot-node-selector. Returns nil when unset or invalid.
+func (r *ServiceDirectorReconciler) getSpotNodeSelector(svc *corev1.Service) labels.Selector {
+	if svc.Annotations == nil {
+		return nil
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationSpotNodeSelectorService])
+	if val == "" {
+		r.clearAnnotationWarning(svc, AnnotationSpotNodeSelectorService)
+		return nil
+	}
+	selector, err := labels.Parse(val)
+	if err != nil {
+		r.recordAnnotationWarning(svc, AnnotationSpotNodeSelectorService, "InvalidSpotNodeSelector",
+			fmt.Sprintf("Invalid %s %q: %v. Spot nodes are not detected.", AnnotationSpotNodeSelectorService, val, err))
+		return nil
+	}
+	r.clearAnnotationWarning(svc, AnnotationSpotNodeSelectorService)
+	return selector
+}
+
+// nodeUnsafeReason returns why a node should not host the leader, or "" if it is safe
+func nodeUnsafeReason(node *corev1.Node, spotSelector labels.Selector) string {
+	if node == nil {
+		return ""
+	}
+	if node.Spec.Unschedulable {
+		return "cordoned"
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			return "NoExecute taint " + taint.Key
+		}
+	}
+	if spotSelector != nil && spotSelector.Matches(labels.Set(node.Labels)) {
+		return "spot node"
+	}
+	return ""
+}
+
+// getUnsafePods returns the pods scheduled on unsafe nodes, keyed by UID with the reason
+func (r *ServiceDirectorReconciler) getUnsafePods(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, logger *sdklog.Logger) map[types.UID]string {
+	spotSelector := r.getSpotNodeSelector(svc)
+	nodes := r.getCandidateNodes(ctx, pods, logger)
+	unsafe := make(map[types.UID]string)
+	for i := range pods {
+		if reason := nodeUnsafeReason(nodes[pods[i].Spec.NodeName], spotSelector); reason != "" {
+			unsafe[pods[i].UID] = reason
+		}
+	}
+	return unsafe
+}
+
+// excludeUnsafePods drops pods on unsafe nodes from candidates
+func excludeUnsafePods(candidates []corev1.Pod, unsafe map[types.UID]string) []corev1.Pod {
+	if len(unsafe) == 0 {
+		return candidates
+	}
+	safe := candidates[:0]
+	for i := range candidates {
+		if _, ok := unsafe[candidates[i].UID]; !ok {
+			safe = append(safe, candidates[i])
+		}
+	}
+	return safe
+}
+
+// hasSafeCandidate reports whether any candidate other than exclude is on a safe node
+func hasSafeCandidate(candidates []corev1.Pod, unsafe map[types.UID]string, exclude types.UID) bool {
+	for i := range candidates {
+		if candidates[i].UID == exclude {
+			continue
+		}
+		if _, ok := unsafe[candidates[i].UID]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sortUnsafeLast stable-sorts pods on unsafe nodes after all other candidates
+func sortUnsafeLast(candidates []corev1.Pod, unsafe map[types.UID]string) {
+	if len(unsafe) == 0 {
+		return
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		_, iUnsafe := unsafe[candidates[i].UID]
+		_, jUnsafe := unsafe[candidates[j].UID]
+		return !iUnsafe && jUnsafe
+	})
+}
+
+// nodeLeadershipChanged reports whether a Node update can change leader eligibility (cordon state or
+// taints) or preference (nodeTopologyChanged)
+func nodeLeadershipChanged(oldNode, newNode *corev1.Node) bool {
+	if nodeTopologyChanged(oldNode, newNode) {
+		return true
+	}
+	if oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable {
+		return true
+	}
+	if len(oldNode.Spec.Taints) != len(newNode.Spec.Taints) {
+		return true
+	}
+	for i := range oldNode.Spec.Taints {
+		if !oldNode.Spec.Taints[i].MatchTaint(&newNode.Spec.Taints[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNodeUnsafeReason(t *testing.T) {
+	spot := labels.SelectorFromSet(labels.Set{"karpenter.sh/capacity-type": "spot"})
+
+	cordoned := newTopologyNode("node-a", "r", "z", nil)
+	cordoned.Spec.Unschedulable = true
+	noExecute := newTopologyNode("node-b", "r", "z", nil)
+	noExecute.Spec.Taints = []corev1.Taint{{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoExecute}}
+	noSchedule := newTopologyNode("node-c", "r", "z", nil)
+	noSchedule.Spec.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}}
+	spotNode := newTopologyNode("node-d", "r", "z", map[string]string{"karpenter.sh/capacity-type": "spot"})
+
+	tests := []struct {
+		name     string
+		node     *corev1.Node
+		selector labels.Selector
+		expected string
+	}{
+		{name: "unknown node", node: nil, selector: spot, expected: ""},
+		{name: "healthy node", node: newTopologyNode("node-e", "r", "z", nil), selector: spot, expected: ""},
+		{name: "cordoned", node: cordoned, selector: spot, expected: "cordoned"},
+		{name: "NoExecute taint", node: noExecute, selector: spot, expected: "NoExecute taint node.kubernetes.io/not-ready"},
+		{name: "NoSchedule taint is safe", node: noSchedule, selector: spot, expected: ""},
+		{name: "spot node", node: spotNode, selector: spot, expected: "spot node"},
+		{name: "spot node without selector", node: spotNode, selector: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeUnsafeReason(tt.node, tt.selector); got != tt.expected {
+				t.Errorf("nodeUnsafeReason() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNodeLeadershipChanged(t *testing.T) {
+	oldNode := newTopologyNode("node-a", "us-east-1", "us-east-1a", nil)
+
+	statusOnly := oldNode.DeepCopy()
+	statusOnly.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	relabeled := newTopologyNode("node-a", "us-east-1", "us-east-1b", nil)
+	cordoned := oldNode.DeepCopy()
+	cordoned.Spec.Unschedulable = true
+	tainted := oldNode.DeepCopy()
+	tainted.Spec.Taints = []corev1.Taint{{Key: "spot-reclaim", Effect: corev1.TaintEffectNoExecute}}
+
+	if nodeLeadershipChanged(oldNode, statusOnly) {
+		t.Error("nodeLeadershipChanged() = true for status-only update")
+	}
+	for name, newNode := range map[string]*corev1.Node{"labels": relabeled, "cordon": cordoned, "taints": tainted} {
+		if !nodeLeadershipChanged(oldNode, newNode) {
+			t.Errorf("nodeLeadershipChanged() = false for %s change", name)
+		}
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderPod_UnsafeNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	cordoned := newTopologyNode("node-cordoned", "r", "z", nil)
+	cordoned.Spec.Unschedulable = true
+	nodes := []client.Object{
+		cordoned,
+		newTopologyNode("node-spot", "r", "z", map[string]string{"capacity-type": "spot"}),
+		newTopologyNode("node-ok", "r", "z", nil),
+	}
+
+	tests := []struct {
+		name         string
+		policy       string
+		stickyLeader string
+		pods         []corev1.Pod
+		expectedPod  string
+		expectEvent  string
+	}{
+		{
+			name:        "ignore policy keeps strategy order",
+			pods:        []corev1.Pod{newPodOnNode("pod-old", "node-cordoned", time.Hour), newPodOnNode("pod-new", "node-ok", time.Minute)},
+			expectedPod: "pod-old",
+		},
+		{
+			name:        "last-resort prefers safe node",
+			policy:      UnsafeNodePolicyLastResort,
+			pods:        []corev1.Pod{newPodOnNode("pod-old", "node-spot", time.Hour), newPodOnNode("pod-new", "node-ok", time.Minute)},
+			expectedPod: "pod-new",
+		},
+		{
+			name:        "last-resort falls back to unsafe node",
+			policy:      UnsafeNodePolicyLastResort,
+			pods:        []corev1.Pod{newPodOnNode("pod-old", "node-cordoned", time.Hour)},
+			expectedPod: "pod-old",
+		},
+		{
+			name:        "exclude leaves no candidate",
+			policy:      UnsafeNodePolicyExclude,
+			pods:        []corev1.Pod{newPodOnNode("pod-old", "node-cordoned", time.Hour)},
+			expectEvent: "NoSafeLeaderCandidates",
+		},
+		{
+			name:         "sticky leader on cordoned node is handed over",
+			policy:       UnsafeNodePolicyLastResort,
+			stickyLeader: "pod-old",
+			pods:         []corev1.Pod{newPodOnNode("pod-old", "node-cordoned", time.Hour), newPodOnNode("pod-new", "node-ok", time.Minute)},
+			expectedPod:  "pod-new",
+			expectEvent:  "LeaderNodeUnsafe",
+		},
+		{
+			name:         "sticky leader kept when no safe alternative",
+			policy:       UnsafeNodePolicyLastResort,
+			stickyLeader: "pod-old",
+			pods:         []corev1.Pod{newPodOnNode("pod-old", "node-cordoned", time.Hour), newPodOnNode("pod-new", "node-spot", time.Minute)},
+			expectedPod:  "pod-old",
+		},
+		{
+			name:        "invalid policy is ignored with event",
+			policy:      "avoid",
+			pods:        []corev1.Pod{newPodOnNode("pod-old", "node-cordoned", time.Hour), newPodOnNode("pod-new", "node-ok", time.Minute)},
+			expectedPod: "pod-old",
+			expectEvent: "InvalidUnsafeNodePolicy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationEnabledService:          "true",
+						AnnotationSpotNodeSelectorService: "capacity-type=spot",
+					},
+				},
+			}
+			if tt.policy != "" {
+				svc.Annotations[AnnotationUnsafeNodePolicyService] = tt.policy
+			}
+			objs := append([]client.Object{svc}, nodes...)
+			if tt.stickyLeader != "" {
+				objs = append(objs, &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{Name: svc.Name + ServiceSuffixService, Namespace: "default"},
+					Endpoints: []discoveryv1.Endpoint{{
+						Addresses: []string{"10.0.0.1"},
+						TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: tt.stickyLeader, UID: types.UID("uid-" + tt.stickyLeader)},
+					}},
+				})
+			}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+				Scheme:        scheme,
+				Recorder:      eventRecorder,
+				Metrics:       metrics.NewRecorder(),
+				nodeAwareness: true,
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			leader := r.selectLeaderPod(context.Background(), svc, tt.pods, false, logger)
+			if tt.expectedPod == "" {
+				if leader != nil {
+					t.Fatalf("selectLeaderPod() = %s, expected no leader", leader.Name)
+				}
+			} else if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderPod() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// AnnotationPreferredZonesService lists preferred zones in order (e.g. "us-east-1a,us-east-1b")
+	AnnotationPreferredZonesService = "zen-lead.io/preferred-zones"
+	// AnnotationPreferredRegionsService lists preferred regions in order (e.g. "us-east-1,us-west-2")
+	AnnotationPreferredRegionsService = "zen-lead.io/preferred-regions"
+	// AnnotationPreferredNodeSelectorService is a label selector for preferred nodes (e.g. "node-type=fast")
+	AnnotationPreferredNodeSelectorService = "zen-lead.io/preferred-node-selector"
+
+	// topologyPreferenceAnnotations names the topology preference annotations in events
+	topologyPreferenceAnnotations = "zen-lead.io/preferred-*"
+
+	// podNodeNameIndex indexes Pods by spec.nodeName for Node -> Service mapping
+	podNodeNameIndex = "spec.nodeName"
+)
+
+// SetNodeAwareness enables the features that read Nodes: topology preference, unsafe node handling and
+// EndpointSlice zones. Off by default: zen-lead neither caches nor watches Nodes unless the operator grants
+// the node RBAC and enables it (--enable-node-awareness).
+func (r *ServiceDirectorReconciler) SetNodeAwareness(enabled bool) {
+	r.nodeAwareness = enabled
+}
+
+// nodeAwarenessDisabled reports whether Node reads are disabled, emitting a NodeAwarenessDisabled Warning
+// event (once per service and annotation) naming the annotation that needs them
+func (r *ServiceDirectorReconciler) nodeAwarenessDisabled(svc *corev1.Service, annotation string) bool {
+	if r.nodeAwareness {
+		return false
+	}
+	r.recordAnnotationWarning(svc, annotation, "NodeAwarenessDisabled",
+		fmt.Sprintf("%s is set but the controller runs without --enable-node-awareness: ignored", annotation))
+	return true
+}
+
+// topologyPreference is the parsed topology preference of a Service
+type topologyPreference struct {
+	zones        []string
+	regions      []string
+	nodeSelector labels.Selector
+}
+
+// getTopologyPreference parses the topology preference annotations of a Service.
+// Returns nil when no preference is configured. An invalid node selector emits a Warning
+// event and is ignored (zones/regions still apply).
+func (r *ServiceDirectorReconciler) getTopologyPreference(svc *corev1.Service) *topologyPreference {
+	if svc.Annotations == nil {
+		return nil
+	}
+	pref := &topologyPreference{
+		zones:   splitCommaList(svc.Annotations[AnnotationPreferredZonesService]),
+		regions: splitCommaList(svc.Annotations[AnnotationPreferredRegionsService]),
+	}
+	if val := strings.TrimSpace(svc.Annotations[AnnotationPreferredNodeSelectorService]); val != "" {
+		selector, err := labels.Parse(val)
+		if err != nil {
+			r.recordAnnotationWarning(svc, AnnotationPreferredNodeSelectorService, "InvalidTopologyPreference",
+				fmt.Sprintf("Invalid %s %q: %v. Node selector preference ignored.", AnnotationPreferredNodeSelectorService, val, err))
+		} else {
+			pref.nodeSelector = selector
+			r.clearAnnotationWarning(svc, AnnotationPreferredNodeSelectorService)
+		}
+	}
+	if len(pref.zones) == 0 && len(pref.regions) == 0 && pref.nodeSelector == nil {
+		r.clearAnnotationWarning(svc, topologyPreferenceAnnotations)
+		return nil
+	}
+	if r.nodeAwarenessDisabled(svc, topologyPreferenceAnnotations) {
+		return nil
+	}
+	return pref
+}
+
+// getCandidateNodes fetches the Nodes that candidates are scheduled on (cached client, keyed by name).
+// Nodes that cannot be fetched map to nil; callers treat them as unknown topology.
+func (r *ServiceDirectorReconciler) getCandidateNodes(ctx context.Context, candidates []corev1.Pod, logger *sdklog.Logger) map[string]*corev1.Node {
+	nodes := make(map[string]*corev1.Node, len(candidates))
+	for i := range candidates {
+		nodeName := candidates[i].Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		if _, seen := nodes[nodeName]; seen {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			logger.Debug("Failed to get node for leader candidate",
+				sdklog.String("pod", candidates[i].Name),
+				sdklog.String("node", nodeName),
+				sdklog.String("error", err.Error()))
+			nodes[nodeName] = nil
+			continue
+		}
+		nodes[nodeName] = node
+	}
+	return nodes
+}
+
+// rank returns the rank of a node for the preference (lower is better).
+// Missing nodes rank after every preferred and non-preferred known node.
+func (p *topologyPreference) rank(node *corev1.Node) [3]int {
+	if node == nil {
+		return [3]int{1, len(p.zones) + 1, len(p.regions) + 1}
+	}
+	selectorMiss := 0
+	if p.nodeSelector != nil && !p.nodeSelector.Matches(labels.Set(node.Labels)) {
+		selectorMiss = 1
+	}
+	return [3]int{
+		selectorMiss,
+		indexOrLen(p.zones, node.Labels[corev1.LabelTopologyZone]),
+		indexOrLen(p.regions, node.Labels[corev1.LabelTopologyRegion]),
+	}
+}
+
+// indexOrLen returns the index of val in list, or len(list) when absent
+func indexOrLen(list []string, val string) int {
+	if val != "" {
+		for i, item := range list {
+			if item == val {
+				return i
+			}
+		}
+	}
+	return len(list)
+}
+
+// sortByTopologyPreference stable-sorts candidates by the Service's topology preference
+// (node selector match, then zone order, then region order). Pods outside the preferred
+// topology stay eligible, so failover to other topologies remains automatic.
+func (r *ServiceDirectorReconciler) sortByTopologyPreference(ctx context.Context, svc *corev1.Service, candidates []corev1.Pod, logger *sdklog.Logger) {
+	pref := r.getTopologyPreference(svc)
+	if pref == nil || len(candidates) < 2 {
+		return
+	}
+	nodes := r.getCandidateNodes(ctx, candidates, logger)
+	ranks := make(map[types.UID][3]int, len(candidates))
+	for i := range candidates {
+		ranks[candidates[i].UID] = pref.rank(nodes[candidates[i].Spec.NodeName])
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := ranks[candidates[i].UID], ranks[candidates[j].UID]
+		for k := range ri {
+			if ri[k] != rj[k] {
+				return ri[k] < rj[k]
+			}
+		}
+		return false
+	})
+}
+
+// indexPodNodeName is the field indexer for podNodeNameIndex
+func indexPodNodeName(obj client.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil
+	}
+	return []string{pod.Spec.NodeName}
+}
+
+// mapNodeToService maps Node changes to reconciles of the Services selecting pods on that node
+func (r *ServiceDirectorReconciler) mapNodeToService(ctx context.Context, obj client.Object) []reconcile.Request {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.MatchingFields{podNodeNameIndex: node.Name}); err != nil {
+		packageLogger.WithContext(ctx).Debug("Failed to list pods on node",
+			sdklog.String("node", node.Name),
+			sdklog.String("error", err.Error()))
+		return nil
+	}
+
+	seen := make(map[types.NamespacedName]struct{})
+	var requests []reconcile.Request
+	for i := range podList.Items {
+		for _, req := range r.mapPodToService(ctx, &podList.Items[i]) {
+			if _, dup := seen[req.NamespacedName]; dup {
+				continue
+			}
+			seen[req.NamespacedName] = struct{}{}
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// nodeTopologyChanged reports whether a Node update can change leader preference
+func nodeTopologyChanged(oldNode, newNode *corev1.Node) bool {
+	return !labels.Equals(oldNode.Labels, newNode.Labels)
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTopologyNode builds a Node in the given region/zone with extra labels
+func newTopologyNode(name, region, zone string, extra map[string]string) *corev1.Node {
+	nodeLabels := map[string]string{
+		corev1.LabelTopologyRegion: region,
+		corev1.LabelTopologyZone:   zone,
+	}
+	for k, v := range extra {
+		nodeLabels[k] = v
+	}
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+}
+
+// newPodOnNode builds a Ready pod scheduled on a node
+func newPodOnNode(name, nodeName string, age time.Duration) corev1.Pod {
+	pod := newReadyPod(name, age)
+	pod.Spec.NodeName = nodeName
+	return pod
+}
+
+func TestServiceDirectorReconciler_SortByTopologyPreference(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	nodes := []client.Object{
+		newTopologyNode("node-a", "us-east-1", "us-east-1a", nil),
+		newTopologyNode("node-b", "us-east-1", "us-east-1b", map[string]string{"node-type": "fast"}),
+		newTopologyNode("node-c", "us-west-2", "us-west-2a", nil),
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		pods        []corev1.Pod
+		expected    []string
+		expectEvent bool
+	}{
+		{
+			name:        "no preference keeps strategy order",
+			annotations: map[string]string{},
+			pods:        []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-c", "node-c", time.Hour)},
+			expected:    []string{"pod-a", "pod-c"},
+		},
+		{
+			name:        "zone order",
+			annotations: map[string]string{AnnotationPreferredZonesService: "us-west-2a, us-east-1b"},
+			pods:        []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-b", "node-b", time.Hour), newPodOnNode("pod-c", "node-c", time.Hour)},
+			expected:    []string{"pod-c", "pod-b", "pod-a"},
+		},
+		{
+			name:        "region order",
+			annotations: map[string]string{AnnotationPreferredRegionsService: "us-west-2"},
+			pods:        []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-c", "node-c", time.Hour)},
+			expected:    []string{"pod-c", "pod-a"},
+		},
+		{
+			name:        "node selector wins over zone",
+			annotations: map[string]string{AnnotationPreferredNodeSelectorService: "node-type=fast", AnnotationPreferredZonesService: "us-east-1a"},
+			pods:        []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-b", "node-b", time.Hour)},
+			expected:    []string{"pod-b", "pod-a"},
+		},
+		{
+			name:        "fallback when no pod in preferred zone",
+			annotations: map[string]string{AnnotationPreferredZonesService: "eu-west-1a"},
+			pods:        []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-c", "node-c", time.Hour)},
+			expected:    []string{"pod-a", "pod-c"},
+		},
+		{
+			name:        "unknown node ranks last",
+			annotations: map[string]string{AnnotationPreferredRegionsService: "eu-west-1"},
+			pods:        []corev1.Pod{newPodOnNode("pod-x", "node-missing", time.Hour), newPodOnNode("pod-a", "node-a", time.Hour)},
+			expected:    []string{"pod-a", "pod-x"},
+		},
+		{
+			name:        "invalid selector is ignored with event",
+			annotations: map[string]string{AnnotationPreferredNodeSelectorService: "node-type in (", AnnotationPreferredZonesService: "us-east-1b"},
+			pods:        []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-b", "node-b", time.Hour)},
+			expected:    []string{"pod-b", "pod-a"},
+			expectEvent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: tt.annotations},
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(nodes...).Build(),
+				Scheme:        scheme,
+				Recorder:      eventRecorder,
+				Metrics:       metrics.NewRecorder(),
+				nodeAwareness: true,
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			r.sortByTopologyPreference(context.Background(), svc, tt.pods, logger)
+
+			got := make([]string, 0, len(tt.pods))
+			for i := range tt.pods {
+				got = append(got, tt.pods[i].Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("sortByTopologyPreference() = %v, expected %v", got, tt.expected)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "InvalidTopologyPreference") {
+					gotEvent = true
+				}
+			}
+			if gotEvent != tt.expectEvent {
+				t.Errorf("InvalidTopologyPreference event = %v, expected %v", gotEvent, tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderPod_TopologyAndPriority(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:        "true",
+				AnnotationPreferredZonesService: "us-east-1b",
+			},
+		},
+	}
+	r := &ServiceDirectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc,
+			newTopologyNode("node-a", "us-east-1", "us-east-1a", nil),
+			newTopologyNode("node-b", "us-east-1", "us-east-1b", nil),
+		).Build(),
+		Scheme:        scheme,
+		Recorder:      record.NewFakeRecorder(10),
+		Metrics:       metrics.NewRecorder(),
+		nodeAwareness: true,
+	}
+	logger := packageLogger.WithContext(context.Background())
+
+	// Preferred zone beats the older pod elsewhere
+	pods := []corev1.Pod{newPodOnNode("pod-old", "node-a", time.Hour), newPodOnNode("pod-new", "node-b", time.Minute)}
+	if leader := r.selectLeaderPod(context.Background(), svc, pods, true, logger); leader == nil || leader.Name != "pod-new" {
+		t.Fatalf("selectLeaderPod() = %v, expected pod-new", leader)
+	}
+
+	// Leader priority still wins over topology
+	pods = []corev1.Pod{newPodOnNode("pod-old", "node-a", time.Hour), newPodOnNode("pod-new", "node-b", time.Minute)}
+	pods[0].Annotations = map[string]string{AnnotationLeaderPriorityPod: "10"}
+	if leader := r.selectLeaderPod(context.Background(), svc, pods, true, logger); leader == nil || leader.Name != "pod-old" {
+		t.Fatalf("selectLeaderPod() = %v, expected pod-old", leader)
+	}
+}
+
+func TestServiceDirectorReconciler_NodeAwarenessDisabled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-service",
+		Namespace: "default",
+		Annotations: map[string]string{
+			AnnotationPreferredZonesService:   "us-east-1b",
+			AnnotationUnsafeNodePolicyService: UnsafeNodePolicyExclude,
+		},
+	}}
+	eventRecorder := record.NewFakeRecorder(10)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(newTopologyNode("node-b", "us-east-1", "us-east-1b", nil)).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+
+	// Every reconcile sorts candidates; the warning is emitted only once
+	for range 3 {
+		pods := []corev1.Pod{newPodOnNode("pod-a", "node-a", time.Hour), newPodOnNode("pod-b", "node-b", time.Hour)}
+		r.sortByTopologyPreference(context.Background(), svc, pods, packageLogger.WithContext(context.Background()))
+		if pods[0].Name != "pod-a" {
+			t.Errorf("sortByTopologyPreference() reordered pods without --enable-node-awareness: %s first", pods[0].Name)
+		}
+	}
+	if policy := r.getUnsafeNodePolicy(svc); policy != UnsafeNodePolicyIgnore {
+		t.Errorf("getUnsafeNodePolicy() = %s, expected %s without --enable-node-awareness", policy, UnsafeNodePolicyIgnore)
+	}
+	disabled := 0
+	for len(eventRecorder.Events) > 0 {
+		if strings.Contains(<-eventRecorder.Events, "NodeAwarenessDisabled") {
+			disabled++
+		}
+	}
+	if disabled != 2 {
+		t.Errorf("NodeAwarenessDisabled events = %d, expected 2", disabled)
+	}
+}
+
+func TestServiceDirectorReconciler_MapNodeToService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationEnabledService: "true"},
+		},
+		Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "my-app"}},
+	}
+	onNode := newPodOnNode("pod-a", "node-a", time.Hour)
+	onNodeToo := newPodOnNode("pod-b", "node-a", time.Hour)
+	elsewhere := newPodOnNode("pod-c", "node-c", time.Hour)
+
+	r := &ServiceDirectorReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(svc, &onNode, &onNodeToo, &elsewhere).
+			WithIndex(&corev1.Pod{}, podNodeNameIndex, indexPodNodeName).
+			Build(),
+		Scheme:               scheme,
+		Recorder:             record.NewFakeRecorder(10),
+		Metrics:              metrics.NewRecorder(),
+		nodeAwareness:        true,
+		optedInServicesCache: make(map[string][]*cachedService),
+		cacheUpdateTimeout:   10 * time.Second,
+	}
+
+	requests := r.mapNodeToService(context.Background(), newTopologyNode("node-a", "us-east-1", "us-east-1a", nil))
+	if len(requests) != 1 || requests[0].Name != "my-service" {
+		t.Errorf("mapNodeToService() = %v, expected a single my-service request", requests)
+	}
+
+	if requests := r.mapNodeToService(context.Background(), newTopologyNode("node-empty", "us-east-1", "us-east-1a", nil)); len(requests) != 0 {
+		t.Errorf("mapNodeToService() for empty node = %v, expected none", requests)
+	}
+}
+
+func TestNodeTopologyChanged(t *testing.T) {
+	oldNode := newTopologyNode("node-a", "us-east-1", "us-east-1a", nil)
+	sameNode := oldNode.DeepCopy()
+	sameNode.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	movedNode := newTopologyNode("node-a", "us-east-1", "us-east-1b", nil)
+
+	if nodeTopologyChanged(oldNode, sameNode) {
+		t.Error("nodeTopologyChanged() = true for status-only update")
+	}
+	if !nodeTopologyChanged(oldNode, movedNode) {
+		t.Error("nodeTopologyChanged() = false for zone label change")
+	}
+}
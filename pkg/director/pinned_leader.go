@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationPinnedLeaderService names a pod that is forced to be leader while it is eligible,
+	// overriding stickiness, strategy and the leader sources (role probe, Lease, label)
+	AnnotationPinnedLeaderService = "zen-lead.io/pinned-leader"
+	// AnnotationPinnedUntilService optionally ends the pin at an RFC3339 timestamp (e.g. "2025-06-01T12:00:00Z")
+	AnnotationPinnedUntilService = "zen-lead.io/pinned-until"
+)
+
+// getPinnedLeader returns the pinned pod while the pin is active and the pod is an eligible candidate,
+// and how long until the pin expires (0 = no expiry). An expired pin or an unavailable pinned pod emits
+// an event and returns nil: normal leader selection resumes.
+func (r *ServiceDirectorReconciler) getPinnedLeader(svc *corev1.Service, pods, candidates []corev1.Pod, logger *sdklog.Logger) (*corev1.Pod, time.Duration) {
+	if svc.Annotations == nil {
+		return nil, 0
+	}
+	name := strings.TrimSpace(svc.Annotations[AnnotationPinnedLeaderService])
+	if name == "" {
+		return nil, 0
+	}
+
+	var remaining time.Duration
+	if val := strings.TrimSpace(svc.Annotations[AnnotationPinnedUntilService]); val != "" {
+		until, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidPinnedUntil",
+				fmt.Sprintf("Invalid %s %q (expected RFC3339): ignoring pin of %s", AnnotationPinnedUntilService, val, name))
+			return nil, 0
+		}
+		if remaining = time.Until(until); remaining <= 0 {
+			logger.Info("Leader pin expired", sdklog.Operation("select_leader"), sdklog.String("pod", name))
+			r.Recorder.Event(svc, corev1.EventTypeNormal, "PinExpired",
+				fmt.Sprintf("Pin of leader %s expired at %s; normal leader selection resumed", name, until.Format(time.RFC3339)))
+			return nil, 0
+		}
+	}
+
+	for i := range candidates {
+		if candidates[i].Name == name {
+			logger.Debug("Using pinned leader", sdklog.String("pod", name))
+			return &candidates[i], remaining
+		}
+	}
+
+	reason := "not found"
+	for i := range pods {
+		if pods[i].Name == name {
+			reason = "not eligible"
+			break
+		}
+	}
+	logger.Info("Pinned leader unavailable", sdklog.Operation("select_leader"), sdklog.String("pod", name), sdklog.String("reason", reason))
+	r.Recorder.Event(svc, corev1.EventTypeWarning, "PinnedLeaderUnavailable",
+		fmt.Sprintf("Pinned leader %s is %s; normal leader selection resumed", name, reason))
+	return nil, 0
+}
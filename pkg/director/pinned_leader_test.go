@@ -0,0 +1,214 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_SelectLeaderPod_PinnedLeader(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	notReady := newReadyPod("pod-new", time.Minute)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		pods        []corev1.Pod
+		expectedPod string
+		expectEvent string
+	}{
+		{
+			name:        "pin overrides strategy",
+			annotations: map[string]string{AnnotationPinnedLeaderService: "pod-new"},
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-new",
+		},
+		{
+			name: "pin active until a future time",
+			annotations: map[string]string{
+				AnnotationPinnedLeaderService: "pod-new",
+				AnnotationPinnedUntilService:  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-new",
+		},
+		{
+			name: "expired pin reverts",
+			annotations: map[string]string{
+				AnnotationPinnedLeaderService: "pod-new",
+				AnnotationPinnedUntilService:  time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+			},
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-old",
+			expectEvent: "PinExpired",
+		},
+		{
+			name:        "pinned pod not Ready reverts",
+			annotations: map[string]string{AnnotationPinnedLeaderService: "pod-new"},
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), notReady},
+			expectedPod: "pod-old",
+			expectEvent: "PinnedLeaderUnavailable",
+		},
+		{
+			name:        "pinned pod gone reverts",
+			annotations: map[string]string{AnnotationPinnedLeaderService: "pod-gone"},
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour)},
+			expectedPod: "pod-old",
+			expectEvent: "PinnedLeaderUnavailable",
+		},
+		{
+			name: "invalid pinned-until ignores the pin",
+			annotations: map[string]string{
+				AnnotationPinnedLeaderService: "pod-new",
+				AnnotationPinnedUntilService:  "tomorrow",
+			},
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-old",
+			expectEvent: "InvalidPinnedUntil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{AnnotationEnabledService: "true"}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: annotations}}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			leader := r.selectLeaderPod(context.Background(), svc, tt.pods, false, logger)
+			if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderPod() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_PinnedLeader(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationEnabledService: "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(50),
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	setAnnotations := func(annotations map[string]string) {
+		t.Helper()
+		current := &corev1.Service{}
+		if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+			t.Fatalf("failed to get service: %v", err)
+		}
+		for k, v := range annotations {
+			current.Annotations[k] = v
+		}
+		if err := r.Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to update service: %v", err)
+		}
+	}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+		t.Fatalf("leader endpoints = %v, expected [pod-a]", got)
+	}
+
+	// Pin pod-b - overrides the sticky leader; requeued at pin expiry
+	setAnnotations(map[string]string{
+		AnnotationPinnedLeaderService: "pod-b",
+		AnnotationPinnedUntilService:  time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339),
+	})
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints while pinned = %v, expected [pod-b]", got)
+	}
+	if result.RequeueAfter <= 9*time.Minute || result.RequeueAfter > 10*time.Minute {
+		t.Errorf("RequeueAfter = %v, expected the remaining pin time", result.RequeueAfter)
+	}
+
+	// Pin expires - normal selection resumes (the former pin stays leader through stickiness)
+	setAnnotations(map[string]string{AnnotationPinnedUntilService: time.Now().Add(-time.Second).UTC().Format(time.RFC3339)})
+	result, err = r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %v after pin expiry, expected none", result.RequeueAfter)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints after pin expiry = %v, expected sticky [pod-b]", got)
+	}
+}
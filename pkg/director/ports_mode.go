@@ -0,0 +1,165 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// PortsModeMirror mirrors every source Service port onto the leader Service (default)
+	PortsModeMirror = "mirror"
+	// PortsModeSubset exposes only the source ports listed in zen-lead.io/ports
+	PortsModeSubset = "subset"
+	// PortsModeRemap exposes source ports under different leader Service port numbers (zen-lead.io/ports)
+	PortsModeRemap = "remap"
+
+	// AnnotationPortsService is the companion annotation for zen-lead.io/ports-mode.
+	// subset: comma-separated source port names or numbers (e.g. "http,grpc")
+	// remap: comma-separated <source port name or number>:<leader port> pairs (e.g. "http:8080,9090:19090")
+	AnnotationPortsService = "zen-lead.io/ports"
+)
+
+// selectServicePorts returns the source Service ports to expose on the leader Service according to
+// zen-lead.io/ports-mode. Invalid configuration returns an error (fail-closed: never expose ports
+// the user meant to hide).
+func selectServicePorts(svc *corev1.Service) ([]corev1.ServicePort, error) {
+	mode := PortsModeMirror
+	spec := ""
+	if svc.Annotations != nil {
+		if val := strings.TrimSpace(svc.Annotations[AnnotationPortsModeService]); val != "" {
+			mode = val
+		}
+		spec = svc.Annotations[AnnotationPortsService]
+	}
+
+	switch mode {
+	case PortsModeMirror:
+		return svc.Spec.Ports, nil
+	case PortsModeSubset:
+		return selectSubsetPorts(svc, spec)
+	case PortsModeRemap:
+		return selectRemappedPorts(svc, spec)
+	default:
+		return nil, fmt.Errorf("unknown %s %q (supported: %s, %s, %s)", AnnotationPortsModeService, mode, PortsModeMirror, PortsModeSubset, PortsModeRemap)
+	}
+}
+
+// selectSubsetPorts keeps only the source ports listed in the companion annotation, in source order
+func selectSubsetPorts(svc *corev1.Service, spec string) ([]corev1.ServicePort, error) {
+	refs := splitCommaList(spec)
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("%s=%s requires %s to list at least one port", AnnotationPortsModeService, PortsModeSubset, AnnotationPortsService)
+	}
+
+	wanted := make(map[int]struct{}, len(refs))
+	for _, ref := range refs {
+		idx, err := findServicePort(svc, ref)
+		if err != nil {
+			return nil, err
+		}
+		wanted[idx] = struct{}{}
+	}
+
+	ports := make([]corev1.ServicePort, 0, len(wanted))
+	for i := range svc.Spec.Ports {
+		if _, ok := wanted[i]; ok {
+			ports = append(ports, svc.Spec.Ports[i])
+		}
+	}
+	return ports, nil
+}
+
+// selectRemappedPorts mirrors all source ports, renumbering the ones listed in the companion annotation.
+// The backend (targetPort) is unchanged, so the leader Service port can differ from the source port.
+func selectRemappedPorts(svc *corev1.Service, spec string) ([]corev1.ServicePort, error) {
+	entries := splitCommaList(spec)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s=%s requires %s to list at least one <port>:<leader-port> pair", AnnotationPortsModeService, PortsModeRemap, AnnotationPortsService)
+	}
+
+	remap := make(map[int]int32, len(entries))
+	for _, entry := range entries {
+		ref, target, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s entry %q: expected <port>:<leader-port>", AnnotationPortsService, entry)
+		}
+		leaderPort, err := strconv.ParseInt(strings.TrimSpace(target), 10, 32)
+		if err != nil || leaderPort < 1 || leaderPort > 65535 {
+			return nil, fmt.Errorf("invalid %s entry %q: leader port must be 1-65535", AnnotationPortsService, entry)
+		}
+		idx, err := findServicePort(svc, strings.TrimSpace(ref))
+		if err != nil {
+			return nil, err
+		}
+		remap[idx] = int32(leaderPort)
+	}
+
+	ports := make([]corev1.ServicePort, 0, len(svc.Spec.Ports))
+	seen := make(map[string]string, len(svc.Spec.Ports))
+	for i := range svc.Spec.Ports {
+		port := *svc.Spec.Ports[i].DeepCopy()
+		if leaderPort, ok := remap[i]; ok {
+			// Keep the backend explicit before renumbering (targetPort defaults to port)
+			if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal == 0 {
+				port.TargetPort = intstr.FromInt32(port.Port)
+			}
+			port.Port = leaderPort
+			port.NodePort = 0 // NodePort belongs to the source port number
+		}
+		key := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+		if other, dup := seen[key]; dup {
+			return nil, fmt.Errorf("%s produces duplicate leader port %s (ports %q and %q)", AnnotationPortsService, key, other, port.Name)
+		}
+		seen[key] = port.Name
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// findServicePort returns the index of the source port matching a port name or number
+func findServicePort(svc *corev1.Service, ref string) (int, error) {
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Name != "" && svc.Spec.Ports[i].Name == ref {
+			return i, nil
+		}
+	}
+	if num, err := strconv.ParseInt(ref, 10, 32); err == nil {
+		for i := range svc.Spec.Ports {
+			if int64(svc.Spec.Ports[i].Port) == num {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %q listed in %s not found in service %s/%s", ref, AnnotationPortsService, svc.Namespace, svc.Name)
+}
+
+// splitCommaList splits a comma-separated annotation value, trimming and dropping empty entries
+func splitCommaList(val string) []string {
+	var out []string
+	for _, part := range strings.Split(val, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newPortsService builds a Service exposing http, grpc and metrics ports
+func newPortsService(annotations map[string]string) *corev1.Service {
+	annotations[AnnotationEnabledService] = "true"
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP},
+				{Name: "grpc", Port: 9090, Protocol: corev1.ProtocolTCP},
+				{Name: "metrics", Port: 9100, TargetPort: intstr.FromInt32(9100), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+func TestSelectServicePorts(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string // name=port->targetPort
+		expectError bool
+	}{
+		{
+			name:        "default mirrors all ports",
+			annotations: map[string]string{},
+			expected:    []string{"http=80->8080", "grpc=9090->0", "metrics=9100->9100"},
+		},
+		{
+			name:        "explicit mirror ignores companion annotation",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeMirror, AnnotationPortsService: "http"},
+			expected:    []string{"http=80->8080", "grpc=9090->0", "metrics=9100->9100"},
+		},
+		{
+			name:        "subset by name and number keeps source order",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeSubset, AnnotationPortsService: "9090, http"},
+			expected:    []string{"http=80->8080", "grpc=9090->0"},
+		},
+		{
+			name:        "subset without port list",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeSubset},
+			expectError: true,
+		},
+		{
+			name:        "subset with unknown port",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeSubset, AnnotationPortsService: "http,admin"},
+			expectError: true,
+		},
+		{
+			name:        "remap keeps backend port",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeRemap, AnnotationPortsService: "http:8081,grpc:19090"},
+			expected:    []string{"http=8081->8080", "grpc=19090->9090", "metrics=9100->9100"},
+		},
+		{
+			name:        "remap with invalid leader port",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeRemap, AnnotationPortsService: "http:70000"},
+			expectError: true,
+		},
+		{
+			name:        "remap with missing separator",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeRemap, AnnotationPortsService: "http"},
+			expectError: true,
+		},
+		{
+			name:        "remap producing duplicate ports",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeRemap, AnnotationPortsService: "http:9100"},
+			expectError: true,
+		},
+		{
+			name:        "unknown mode",
+			annotations: map[string]string{AnnotationPortsModeService: "passthrough"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPortsService(tt.annotations)
+			ports, err := selectServicePorts(svc)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("selectServicePorts() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			got := make([]string, 0, len(ports))
+			for _, p := range ports {
+				got = append(got, fmt.Sprintf("%s=%d->%s", p.Name, p.Port, p.TargetPort.String()))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("selectServicePorts() = %v, expected %v", got, tt.expected)
+			}
+			// Source Service must never be mutated
+			if svc.Spec.Ports[0].Port != 80 || svc.Spec.Ports[1].TargetPort.IntVal != 0 {
+				t.Errorf("selectServicePorts() mutated source ports: %v", svc.Spec.Ports)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_PortsMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		expectedSvcPorts  []int32
+		expectedEndpoints []int32
+		expectEvent       string
+	}{
+		{
+			name:              "subset hides metrics port",
+			annotations:       map[string]string{AnnotationPortsModeService: PortsModeSubset, AnnotationPortsService: "http"},
+			expectedSvcPorts:  []int32{80},
+			expectedEndpoints: []int32{8080},
+		},
+		{
+			name:              "remap renumbers leader port",
+			annotations:       map[string]string{AnnotationPortsModeService: PortsModeRemap, AnnotationPortsService: "http:8081,grpc:19090"},
+			expectedSvcPorts:  []int32{8081, 19090, 9100},
+			expectedEndpoints: []int32{8080, 9090, 9100},
+		},
+		{
+			name:        "invalid config fails closed",
+			annotations: map[string]string{AnnotationPortsModeService: PortsModeSubset, AnnotationPortsService: "admin"},
+			expectEvent: "InvalidPortsMode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newPortsService(tt.annotations)
+			pod := newReadyPod("pod-1", time.Hour)
+
+			eventRecorder := record.NewFakeRecorder(20)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, &pod).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			leaderSvc := &corev1.Service{}
+			if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, leaderSvc); err != nil {
+				t.Fatalf("failed to get leader service: %v", err)
+			}
+			if len(leaderSvc.Spec.Ports) != len(tt.expectedSvcPorts) {
+				t.Fatalf("leader service has %d ports, expected %d", len(leaderSvc.Spec.Ports), len(tt.expectedSvcPorts))
+			}
+			for i, port := range tt.expectedSvcPorts {
+				if leaderSvc.Spec.Ports[i].Port != port {
+					t.Errorf("leader service port[%d] = %d, expected %d", i, leaderSvc.Spec.Ports[i].Port, port)
+				}
+			}
+
+			slice := &discoveryv1.EndpointSlice{}
+			if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, slice); err != nil {
+				t.Fatalf("failed to get EndpointSlice: %v", err)
+			}
+			if tt.expectedEndpoints == nil {
+				// Fail-closed: the leader Service routes nowhere until the ports configuration is fixed
+				for _, endpoint := range slice.Endpoints {
+					if len(endpoint.Addresses) != 0 {
+						t.Errorf("EndpointSlice routes to %v, expected no addresses when ports configuration is invalid", endpoint.Addresses)
+					}
+				}
+				if len(slice.Ports) != 0 {
+					t.Errorf("EndpointSlice has %d ports, expected none when ports configuration is invalid", len(slice.Ports))
+				}
+			} else {
+				if len(slice.Ports) != len(tt.expectedEndpoints) {
+					t.Fatalf("EndpointSlice has %d ports, expected %d", len(slice.Ports), len(tt.expectedEndpoints))
+				}
+				for i, port := range tt.expectedEndpoints {
+					if *slice.Ports[i].Port != port {
+						t.Errorf("EndpointSlice port[%d] = %d, expected %d", i, *slice.Ports[i].Port, port)
+					}
+				}
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
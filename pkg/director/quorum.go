@@ -0,0 +1,127 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationMinReadyPodsService requires at least M Ready pods before any leader endpoint is published
+	// (e.g. "2" for a 3-node clustered store). Unset or 0 = no quorum guard.
+	AnnotationMinReadyPodsService = "zen-lead.io/min-ready-pods"
+	// AnnotationQuorumLossPolicyService decides what happens to an existing leader when the quorum is lost:
+	// "withdraw" (default) removes it from the leader Service, "keep" keeps it (but never elects a new one)
+	AnnotationQuorumLossPolicyService = "zen-lead.io/quorum-loss-policy"
+
+	// Quorum loss policies
+	QuorumLossWithdraw = "withdraw"
+	QuorumLossKeep     = "keep"
+)
+
+// getMinReadyPods returns the quorum size and loss policy (0 = no quorum guard). Invalid settings emit a
+// Warning event; an invalid policy falls back to withdraw, the safe choice for clustered stores.
+func (r *ServiceDirectorReconciler) getMinReadyPods(svc *corev1.Service) (int, string) {
+	if svc.Annotations == nil {
+		return 0, ""
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationMinReadyPodsService])
+	if val == "" {
+		return 0, ""
+	}
+	minReady, err := strconv.Atoi(val)
+	if err != nil || minReady < 0 {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidMinReadyPods",
+			fmt.Sprintf("Invalid %s %q (expected a non-negative integer): quorum guard disabled", AnnotationMinReadyPodsService, val))
+		return 0, ""
+	}
+
+	policy := QuorumLossWithdraw
+	if val := strings.TrimSpace(svc.Annotations[AnnotationQuorumLossPolicyService]); val != "" {
+		if val == QuorumLossKeep || val == QuorumLossWithdraw {
+			policy = val
+		} else {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidQuorumLossPolicy",
+				fmt.Sprintf("Invalid %s %q (expected %q or %q): using %q", AnnotationQuorumLossPolicyService, val, QuorumLossWithdraw, QuorumLossKeep, QuorumLossWithdraw))
+		}
+	}
+	return minReady, policy
+}
+
+// applyQuorumGuard enforces zen-lead.io/min-ready-pods on the selected leader. With fewer Ready pods than
+// required no new leader is published: the current leader is withdrawn or kept per the loss policy.
+// Returns the leader to publish and whether the quorum is lost. Events fire on loss and restoration.
+func (r *ServiceDirectorReconciler) applyQuorumGuard(svc *corev1.Service, currentLeader, leaderPod *corev1.Pod, readyPods int, logger *sdklog.Logger) (*corev1.Pod, bool) {
+	serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+	minReady, policy := r.getMinReadyPods(svc)
+	if minReady == 0 {
+		r.setQuorumLost(serviceKey, false)
+		return leaderPod, false
+	}
+
+	met := readyPods >= minReady
+	if r.Metrics != nil {
+		r.Metrics.RecordQuorumMet(svc.Namespace, svc.Name, met)
+	}
+	wasLost := r.setQuorumLost(serviceKey, !met)
+	if met {
+		if wasLost {
+			logger.Info("Quorum restored", sdklog.Operation("quorum"), sdklog.Int("readyPods", readyPods), sdklog.Int("minReadyPods", minReady))
+			r.Recorder.Event(svc, corev1.EventTypeNormal, "QuorumRestored",
+				fmt.Sprintf("%d of %d required pods Ready (%s); leader routing resumed", readyPods, minReady, AnnotationMinReadyPodsService))
+		}
+		return leaderPod, false
+	}
+
+	var kept *corev1.Pod
+	outcome := "leader withdrawn"
+	if policy == QuorumLossKeep && currentLeader != nil {
+		kept = currentLeader
+		outcome = fmt.Sprintf("keeping leader %s", currentLeader.Name)
+	} else if policy == QuorumLossKeep {
+		outcome = "no leader to keep"
+	}
+	if !wasLost {
+		logger.Info("Quorum lost", sdklog.Operation("quorum"), sdklog.Int("readyPods", readyPods),
+			sdklog.Int("minReadyPods", minReady), sdklog.String("policy", policy))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "QuorumLost",
+			fmt.Sprintf("Only %d of %d required pods Ready (%s); %s (%s=%s)", readyPods, minReady, AnnotationMinReadyPodsService,
+				outcome, AnnotationQuorumLossPolicyService, policy))
+	}
+	return kept, true
+}
+
+// setQuorumLost records the Service's quorum state and returns the previous one
+func (r *ServiceDirectorReconciler) setQuorumLost(serviceKey string, lost bool) bool {
+	r.quorumLostMu.Lock()
+	defer r.quorumLostMu.Unlock()
+	wasLost := r.quorumLost[serviceKey]
+	if !lost {
+		delete(r.quorumLost, serviceKey)
+		return wasLost
+	}
+	if r.quorumLost == nil {
+		r.quorumLost = make(map[string]bool)
+	}
+	r.quorumLost[serviceKey] = true
+	return wasLost
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_Reconcile_MinReadyPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name            string
+		policy          string
+		expectedWhenLow string
+	}{
+		{name: "withdraw by default", policy: "", expectedWhenLow: ""},
+		{name: "keep existing leader", policy: QuorumLossKeep, expectedWhenLow: "pod-a"},
+		{name: "invalid policy withdraws", policy: "maybe", expectedWhenLow: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{
+				AnnotationEnabledService:      "true",
+				AnnotationMinReadyPodsService: "2",
+			}
+			if tt.policy != "" {
+				annotations[AnnotationQuorumLossPolicyService] = tt.policy
+			}
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: annotations},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{"app": "my-app"},
+					Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+				},
+			}
+			podA := newActivePod("pod-a", "10.0.0.1", 3*time.Hour, 8080)
+			podB := newActivePod("pod-b", "10.0.0.2", 2*time.Hour, 8080)
+			podC := newActivePod("pod-c", "10.0.0.3", time.Hour, 8080)
+
+			eventRecorder := record.NewFakeRecorder(100)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB, podC).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+			setReady := func(pod *corev1.Pod, status corev1.ConditionStatus) {
+				t.Helper()
+				current := &corev1.Pod{}
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), current); err != nil {
+					t.Fatalf("failed to get pod: %v", err)
+				}
+				current.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+				if err := r.Status().Update(context.Background(), current); err != nil {
+					t.Fatalf("failed to update pod status: %v", err)
+				}
+			}
+			reconcileExpecting := func(expectedLeaders, expectEvent string) {
+				t.Helper()
+				if _, err := r.Reconcile(context.Background(), req); err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+				if got := activeEndpointNames(t, r); strings.Join(got, ",") != expectedLeaders {
+					t.Fatalf("leader endpoints = %v, expected [%s]", got, expectedLeaders)
+				}
+				gotEvent := false
+				for len(eventRecorder.Events) > 0 {
+					event := <-eventRecorder.Events
+					if expectEvent != "" && strings.Contains(event, expectEvent) {
+						gotEvent = true
+					} else if expectEvent == "" && (strings.Contains(event, "QuorumLost") || strings.Contains(event, "QuorumRestored")) {
+						t.Errorf("unexpected event: %s", event)
+					}
+				}
+				if expectEvent != "" && !gotEvent {
+					t.Errorf("expected %s event", expectEvent)
+				}
+			}
+
+			reconcileExpecting("pod-a", "")
+
+			// Partition - only the leader remains Ready
+			setReady(podB, corev1.ConditionFalse)
+			setReady(podC, corev1.ConditionFalse)
+			reconcileExpecting(tt.expectedWhenLow, "QuorumLost")
+
+			// Still below quorum - no repeated loss event
+			reconcileExpecting(tt.expectedWhenLow, "")
+
+			// Quorum back
+			setReady(podB, corev1.ConditionTrue)
+			reconcileExpecting("pod-a", "QuorumRestored")
+		})
+	}
+}
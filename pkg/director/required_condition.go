@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// AnnotationRequiredConditionService names a pod condition type (e.g. a readiness gate such as
+// "example.com/replication-caught-up") that must be True before a pod can become leader
+const AnnotationRequiredConditionService = "zen-lead.io/required-condition"
+
+// parseRequiredCondition parses zen-lead.io/required-condition. Returns "" when unset or invalid.
+func parseRequiredCondition(svc *corev1.Service) (corev1.PodConditionType, error) {
+	if svc.Annotations == nil {
+		return "", nil
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationRequiredConditionService])
+	if val == "" {
+		return "", nil
+	}
+	// Pod condition types (readiness gates) are qualified names
+	if errs := validation.IsQualifiedName(val); len(errs) > 0 {
+		return "", fmt.Errorf("%q is not a valid condition type: %s", val, strings.Join(errs, "; "))
+	}
+	return corev1.PodConditionType(val), nil
+}
+
+// hasRequiredCondition reports whether the required pod condition (if any) is True
+func (e podEligibility) hasRequiredCondition(pod *corev1.Pod) bool {
+	if e.requiredCondition == "" {
+		return true
+	}
+	condition := findPodCondition(pod, e.requiredCondition)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// findPodCondition returns the pod condition of the given type, or nil if absent
+func findPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// podConditionsChanged reports whether any pod condition was added, removed or changed status
+// (pod watch predicate - the watch does not know which condition a Service requires)
+func podConditionsChanged(oldPod, newPod *corev1.Pod) bool {
+	if len(oldPod.Status.Conditions) != len(newPod.Status.Conditions) {
+		return true
+	}
+	for i := range newPod.Status.Conditions {
+		oldCondition := findPodCondition(oldPod, newPod.Status.Conditions[i].Type)
+		if oldCondition == nil || oldCondition.Status != newPod.Status.Conditions[i].Status {
+			return true
+		}
+	}
+	return false
+}
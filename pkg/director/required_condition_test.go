@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testLeaderEligibleCondition = corev1.PodConditionType("example.com/leader-eligible")
+
+// withPodCondition returns the pod with an additional condition of the given status
+func withPodCondition(pod corev1.Pod, conditionType corev1.PodConditionType, status corev1.ConditionStatus) corev1.Pod {
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+	})
+	return pod
+}
+
+func TestServiceDirectorReconciler_SelectLeaderPod_RequiredCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	notReady := newReadyPod("pod-old", time.Hour)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	tests := []struct {
+		name        string
+		condition   string
+		pods        []corev1.Pod
+		expectedPod string
+		expectEvent string
+	}{
+		{
+			name:        "no required condition",
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour), newReadyPod("pod-new", time.Minute)},
+			expectedPod: "pod-old",
+		},
+		{
+			name:      "only pods with condition True are candidates",
+			condition: string(testLeaderEligibleCondition),
+			pods: []corev1.Pod{
+				withPodCondition(newReadyPod("pod-old", time.Hour), testLeaderEligibleCondition, corev1.ConditionFalse),
+				withPodCondition(newReadyPod("pod-new", time.Minute), testLeaderEligibleCondition, corev1.ConditionTrue),
+			},
+			expectedPod: "pod-new",
+		},
+		{
+			name:      "condition alone does not make a NotReady pod eligible",
+			condition: string(testLeaderEligibleCondition),
+			pods: []corev1.Pod{
+				withPodCondition(notReady, testLeaderEligibleCondition, corev1.ConditionTrue),
+			},
+			expectedPod: "",
+		},
+		{
+			name:        "no pod has the condition",
+			condition:   string(testLeaderEligibleCondition),
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour)},
+			expectedPod: "",
+		},
+		{
+			name:        "invalid condition type is ignored with event",
+			condition:   "not a condition!",
+			pods:        []corev1.Pod{newReadyPod("pod-old", time.Hour)},
+			expectedPod: "pod-old",
+			expectEvent: "InvalidRequiredCondition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationEnabledService:           "true",
+						AnnotationRequiredConditionService: tt.condition,
+					},
+				},
+			}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			leader := r.selectLeaderPod(context.Background(), svc, tt.pods, false, logger)
+			if tt.expectedPod == "" {
+				if leader != nil {
+					t.Fatalf("selectLeaderPod() = %s, expected no leader", leader.Name)
+				}
+			} else if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderPod() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestPodConditionsChanged(t *testing.T) {
+	oldPod := newReadyPod("pod-a", time.Hour)
+	gated := withPodCondition(oldPod, testLeaderEligibleCondition, corev1.ConditionFalse)
+	promoted := withPodCondition(oldPod, testLeaderEligibleCondition, corev1.ConditionTrue)
+	probed := gated.DeepCopy()
+	probed.Status.Conditions[1].LastProbeTime = metav1.Now()
+
+	if podConditionsChanged(&gated, probed) {
+		t.Error("podConditionsChanged() = true for probe time update")
+	}
+	if !podConditionsChanged(&oldPod, &gated) {
+		t.Error("podConditionsChanged() = false for added condition")
+	}
+	if !podConditionsChanged(&gated, &promoted) {
+		t.Error("podConditionsChanged() = false for condition status change")
+	}
+}
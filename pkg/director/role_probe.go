@@ -0,0 +1,539 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AnnotationRoleProbeService lets the application decide the primary: each eligible pod is probed and
+	// only the pod reporting itself primary becomes leader (e.g. "http://:8008/primary", 200 = primary).
+	// Native detectors: "redis://:6379", "postgres://:5432/<database>[?sslmode=...]", "mysql://:3306[?tls=true]".
+	AnnotationRoleProbeService = "zen-lead.io/role-probe"
+	// AnnotationRoleProbeSecretService names a Secret in the Service namespace holding the probe
+	// credentials ("username" and "password" keys). The Secret must carry LabelRoleProbeCredentials.
+	AnnotationRoleProbeSecretService = "zen-lead.io/role-probe-secret"
+	// AnnotationRoleProbeAllowPlaintextService allows credentials to be sent without TLS ("true"):
+	// HTTP basic auth over http://, Redis AUTH, PostgreSQL cleartext password authentication and MySQL
+	// passwords without TLS
+	AnnotationRoleProbeAllowPlaintextService = "zen-lead.io/role-probe-allow-plaintext-credentials"
+	// LabelRoleProbeCredentials opts a Secret in to being read as role probe credentials ("true")
+	LabelRoleProbeCredentials = "zen-lead.io/role-probe-credentials"
+	// AnnotationRoleProbeTimeoutService bounds each probe (default 1s)
+	AnnotationRoleProbeTimeoutService = "zen-lead.io/role-probe-timeout"
+	// AnnotationRoleProbeIntervalService sets how often roles are re-probed (default 10s)
+	AnnotationRoleProbeIntervalService = "zen-lead.io/role-probe-interval"
+	// AnnotationRoleProbeFailureThresholdService is how many consecutive failed probes of the current leader
+	// are tolerated before it is dropped (default 3). A leader that answers "not primary" is dropped at once.
+	AnnotationRoleProbeFailureThresholdService = "zen-lead.io/role-probe-failure-threshold"
+
+	defaultRoleProbeTimeout          = time.Second
+	defaultRoleProbeInterval         = 10 * time.Second
+	defaultRoleProbeConcurrency      = 10
+	defaultRoleProbeFailureThreshold = 3
+
+	// Role probe results (metrics label)
+	RoleProbeResultPrimary = "primary"
+	RoleProbeResultReplica = "replica"
+	RoleProbeResultError   = "error"
+	RoleProbeResultTimeout = "timeout"
+
+	// Role probe Secret keys
+	RoleProbeSecretUsernameKey = "username"
+	RoleProbeSecretPasswordKey = "password"
+)
+
+// roleProbe is a parsed zen-lead.io/role-probe configuration
+type roleProbe struct {
+	url              *url.URL
+	timeout          time.Duration
+	interval         time.Duration
+	failureThreshold int
+	secretName       string
+	// allowPlaintext permits sending the credentials without TLS
+	allowPlaintext bool
+
+	// Credentials loaded from secretName before probing
+	username string
+	password string
+}
+
+// roleProbeRound is the outcome of the last role probe round of a Service. Reconciles within the
+// probe interval reuse it instead of probing every pod again on each pod event.
+type roleProbeRound struct {
+	signature      string // candidate pods (name and IP) that were probed
+	probedAt       time.Time
+	leader         types.UID // selected leader ("" = none)
+	leaderFailures int       // consecutive failed probes of the held leader
+}
+
+// roleProber asks a pod whether it is the application primary
+type roleProber interface {
+	isPrimary(ctx context.Context, probe *roleProbe, pod *corev1.Pod) (bool, error)
+}
+
+// defaultRoleProbeHTTPClient does not follow redirects and, like kubelet HTTPS probes,
+// does not verify certificates (pods are addressed by IP)
+var defaultRoleProbeHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // probes address pods by IP, as kubelet does
+		DisableKeepAlives: true,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+// SetRoleProbeConcurrency sets how many pods of one Service are probed in parallel (0 = default 10)
+func (r *ServiceDirectorReconciler) SetRoleProbeConcurrency(concurrency int) {
+	r.roleProbeConcurrency = concurrency
+}
+
+// SetRoleProbeSecrets enables zen-lead.io/role-probe-secret. Off by default: zen-lead reads no Secrets
+// unless started with --enable-role-probe-secrets and granted config/rbac/role-probe-secrets.
+func (r *ServiceDirectorReconciler) SetRoleProbeSecrets(enabled bool) {
+	r.roleProbeSecrets = enabled
+}
+
+// hasRoleProbe reports whether zen-lead.io/role-probe is set
+func hasRoleProbe(svc *corev1.Service) bool {
+	return svc.Annotations != nil && strings.TrimSpace(svc.Annotations[AnnotationRoleProbeService]) != ""
+}
+
+// parseRoleProbe parses zen-lead.io/role-probe and its timeout/interval annotations
+func parseRoleProbe(svc *corev1.Service) (*roleProbe, error) {
+	val := strings.TrimSpace(svc.Annotations[AnnotationRoleProbeService])
+	u, err := url.Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", val, err)
+	}
+	if _, ok := roleProbeDefaultPorts[u.Scheme]; !ok {
+		return nil, fmt.Errorf("unsupported scheme %q in %q", u.Scheme, val)
+	}
+	if u.Hostname() != "" {
+		return nil, fmt.Errorf("host must be empty in %q (each pod is probed on its own IP)", val)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("credentials are not allowed in %q (use %s)", val, AnnotationRoleProbeSecretService)
+	}
+	if u.Scheme == "postgres" && !postgresSSLModes[postgresSSLMode(u)] {
+		return nil, fmt.Errorf("unsupported sslmode %q in %q", postgresSSLMode(u), val)
+	}
+
+	probe := &roleProbe{
+		url:              u,
+		timeout:          defaultRoleProbeTimeout,
+		interval:         defaultRoleProbeInterval,
+		failureThreshold: defaultRoleProbeFailureThreshold,
+		secretName:       strings.TrimSpace(svc.Annotations[AnnotationRoleProbeSecretService]),
+		allowPlaintext:   svc.Annotations[AnnotationRoleProbeAllowPlaintextService] == "true",
+	}
+	if s := strings.TrimSpace(svc.Annotations[AnnotationRoleProbeTimeoutService]); s != "" {
+		if probe.timeout, err = time.ParseDuration(s); err != nil || probe.timeout <= 0 {
+			return nil, fmt.Errorf("invalid %s %q", AnnotationRoleProbeTimeoutService, s)
+		}
+	}
+	if s := strings.TrimSpace(svc.Annotations[AnnotationRoleProbeIntervalService]); s != "" {
+		if probe.interval, err = time.ParseDuration(s); err != nil || probe.interval <= 0 {
+			return nil, fmt.Errorf("invalid %s %q", AnnotationRoleProbeIntervalService, s)
+		}
+	}
+	if s := strings.TrimSpace(svc.Annotations[AnnotationRoleProbeFailureThresholdService]); s != "" {
+		if probe.failureThreshold, err = strconv.Atoi(s); err != nil || probe.failureThreshold < 1 {
+			return nil, fmt.Errorf("invalid %s %q", AnnotationRoleProbeFailureThresholdService, s)
+		}
+	}
+	return probe, nil
+}
+
+// roleProbeDefaultPorts lists the supported role probe schemes and their default ports
+var roleProbeDefaultPorts = map[string]string{
+	"http":     "80",
+	"https":    "443",
+	"redis":    "6379",
+	"postgres": "5432",
+	"mysql":    "3306",
+}
+
+// getRoleProbe returns the role probe configuration and whether one is configured.
+// An invalid configuration emits a Warning event and returns (nil, true): no pod can be confirmed
+// primary, so the leader Service fails closed.
+func (r *ServiceDirectorReconciler) getRoleProbe(svc *corev1.Service) (*roleProbe, bool) {
+	if !hasRoleProbe(svc) {
+		return nil, false
+	}
+	probe, err := parseRoleProbe(svc)
+	if err != nil {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidRoleProbe",
+			fmt.Sprintf("Invalid role probe: %v. Leader Service will have no endpoints until fixed.", err))
+		return nil, true
+	}
+	return probe, true
+}
+
+// roleProberFor returns the prober for a role probe URL scheme
+func (r *ServiceDirectorReconciler) roleProberFor(scheme string) roleProber {
+	switch scheme {
+	case "redis":
+		return &redisRoleProber{}
+	case "postgres":
+		return &postgresRoleProber{}
+	case "mysql":
+		return &mysqlRoleProber{}
+	}
+	client := r.roleProbeHTTPClient
+	if client == nil {
+		client = defaultRoleProbeHTTPClient
+	}
+	return &httpRoleProber{client: client}
+}
+
+// loadRoleProbeCredentials reads the username and password from the role probe Secret (if any).
+// Only Secrets labelled zen-lead.io/role-probe-credentials=true are used.
+func (r *ServiceDirectorReconciler) loadRoleProbeCredentials(ctx context.Context, svc *corev1.Service, probe *roleProbe) error {
+	if probe.secretName == "" {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: probe.secretName}, secret); err != nil {
+		return err
+	}
+	if secret.Labels[LabelRoleProbeCredentials] != "true" {
+		return fmt.Errorf("secret is not labelled %s=true", LabelRoleProbeCredentials)
+	}
+	probe.username = string(secret.Data[RoleProbeSecretUsernameKey])
+	probe.password = string(secret.Data[RoleProbeSecretPasswordKey])
+	return nil
+}
+
+// sendsPlaintextCredentials reports whether the probe would send its credentials without TLS
+// (HTTP basic auth over http://, Redis AUTH, a MySQL password without ?tls=true). PostgreSQL
+// chooses its authentication method at connection time, so cleartext password authentication is
+// refused during the exchange instead.
+func (p *roleProbe) sendsPlaintextCredentials() bool {
+	switch p.url.Scheme {
+	case "http":
+		return p.username != "" || p.password != ""
+	case "redis":
+		return p.password != ""
+	case "mysql":
+		return p.password != "" && !mysqlUsesTLS(p.url)
+	}
+	return false
+}
+
+// roleProbePort returns the probe port (URL port or the scheme default)
+func roleProbePort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	return roleProbeDefaultPorts[u.Scheme]
+}
+
+// dialRoleProbe opens a TCP connection to the pod's probe port. The connection deadline follows the
+// probe context, so every protocol exchange is bounded by the probe timeout.
+func dialRoleProbe(ctx context.Context, probe *roleProbe, pod *corev1.Pod) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(pod.Status.PodIP, roleProbePort(probe.url)))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// httpRoleProber treats HTTP 200 as primary and any other status as not primary
+type httpRoleProber struct {
+	client *http.Client
+}
+
+func (p *httpRoleProber) isPrimary(ctx context.Context, probe *roleProbe, pod *corev1.Pod) (bool, error) {
+	target := *probe.url
+	target.Host = net.JoinHostPort(pod.Status.PodIP, roleProbePort(probe.url))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	if probe.username != "" || probe.password != "" {
+		req.SetBasicAuth(probe.username, probe.password)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// probePodRoles probes all pods with an IP in parallel (bounded by the role probe concurrency).
+// Returns the pods reporting primary (in input order) and the names of pods whose probe failed.
+func (r *ServiceDirectorReconciler) probePodRoles(ctx context.Context, svc *corev1.Service, probe *roleProbe, pods []corev1.Pod, logger *sdklog.Logger) ([]*corev1.Pod, []string) {
+	concurrency := r.roleProbeConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultRoleProbeConcurrency
+	}
+	prober := r.roleProberFor(probe.url.Scheme)
+
+	results := make([]string, len(pods))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range pods {
+		if pods[i].Status.PodIP == "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(pod *corev1.Pod, result *string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if r.Metrics != nil {
+				r.Metrics.IncRoleProbesInFlight()
+				defer r.Metrics.DecRoleProbesInFlight()
+			}
+			probeCtx, cancel := context.WithTimeout(ctx, probe.timeout)
+			defer cancel()
+			start := time.Now()
+			primary, err := prober.isPrimary(probeCtx, probe, pod)
+			switch {
+			case err != nil && isTimeoutError(err):
+				*result = RoleProbeResultTimeout
+			case err != nil:
+				*result = RoleProbeResultError
+			case primary:
+				*result = RoleProbeResultPrimary
+			default:
+				*result = RoleProbeResultReplica
+			}
+			if err != nil {
+				logger.Debug("Role probe failed",
+					sdklog.String("pod", pod.Name),
+					sdklog.String("result", *result),
+					sdklog.String("error", err.Error()))
+			}
+			if r.Metrics != nil {
+				r.Metrics.RecordRoleProbe(svc.Namespace, svc.Name, *result, time.Since(start).Seconds())
+			}
+		}(&pods[i], &results[i])
+	}
+	wg.Wait()
+
+	var primaries []*corev1.Pod
+	var failed []string
+	for i, result := range results {
+		switch result {
+		case RoleProbeResultPrimary:
+			primaries = append(primaries, &pods[i])
+		case RoleProbeResultError, RoleProbeResultTimeout:
+			failed = append(failed, pods[i].Name)
+		}
+	}
+	return primaries, failed
+}
+
+// isTimeoutError reports whether a probe error is a timeout
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// roleProbeSignature identifies the candidates of a probe round (order-independent)
+func roleProbeSignature(candidates []corev1.Pod) string {
+	keys := make([]string, 0, len(candidates))
+	for i := range candidates {
+		keys = append(keys, candidates[i].Name+"="+candidates[i].Status.PodIP)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// lastRoleProbeRound returns the last probe round of a Service, or nil
+func (r *ServiceDirectorReconciler) lastRoleProbeRound(serviceKey string) *roleProbeRound {
+	r.roleProbeRoundsMu.Lock()
+	defer r.roleProbeRoundsMu.Unlock()
+	return r.roleProbeRounds[serviceKey]
+}
+
+// setRoleProbeRound records the outcome of a probe round
+func (r *ServiceDirectorReconciler) setRoleProbeRound(serviceKey string, round *roleProbeRound) {
+	r.roleProbeRoundsMu.Lock()
+	defer r.roleProbeRoundsMu.Unlock()
+	if r.roleProbeRounds == nil {
+		r.roleProbeRounds = make(map[string]*roleProbeRound)
+	}
+	r.roleProbeRounds[serviceKey] = round
+}
+
+// forgetRoleProbeRound drops the probe state of a Service (opt-out or deletion)
+func (r *ServiceDirectorReconciler) forgetRoleProbeRound(serviceKey string) {
+	r.roleProbeRoundsMu.Lock()
+	defer r.roleProbeRoundsMu.Unlock()
+	delete(r.roleProbeRounds, serviceKey)
+}
+
+// selectLeaderByRoleProbe returns the single candidate reporting itself primary. It never guesses:
+// when zero or several candidates claim the role, there is no leader. The exception is a current
+// leader whose probe fails (timeout, connection error) while no other pod claims the role: it is
+// kept until its probe has failed failureThreshold times in a row, so one slow answer does not
+// empty the leader Service.
+//
+// Probing is rate limited: while the candidates are unchanged, reconciles within the probe interval
+// reuse the last round. The second return value is when the next probe round is due.
+func (r *ServiceDirectorReconciler) selectLeaderByRoleProbe(ctx context.Context, svc *corev1.Service, probe *roleProbe, currentLeader *corev1.Pod, candidates []corev1.Pod, logger *sdklog.Logger) (*corev1.Pod, time.Duration) {
+	if probe == nil {
+		return nil, 0
+	}
+	serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+	signature := roleProbeSignature(candidates)
+	last := r.lastRoleProbeRound(serviceKey)
+	if last != nil && last.signature == signature {
+		if age := time.Since(last.probedAt); age < probe.interval {
+			logger.Debug("Reusing role probe round", sdklog.String("age", age.String()))
+			return findPodByUID(candidates, last.leader), probe.interval - age
+		}
+	}
+
+	leader, leaderFailures := r.probeLeaderRole(ctx, svc, probe, currentLeader, last, candidates, logger)
+	round := &roleProbeRound{signature: signature, probedAt: time.Now(), leaderFailures: leaderFailures}
+	if leader != nil {
+		round.leader = leader.UID
+	}
+	r.setRoleProbeRound(serviceKey, round)
+	return leader, probe.interval
+}
+
+// findPodByUID returns the pod with the given UID, or nil
+func findPodByUID(pods []corev1.Pod, uid types.UID) *corev1.Pod {
+	if uid == "" {
+		return nil
+	}
+	for i := range pods {
+		if pods[i].UID == uid {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+// probeLeaderRole runs one probe round and picks the leader. Returns the leader and, when a current
+// leader is held despite a failed probe, its consecutive failure count.
+func (r *ServiceDirectorReconciler) probeLeaderRole(ctx context.Context, svc *corev1.Service, probe *roleProbe, currentLeader *corev1.Pod, last *roleProbeRound, candidates []corev1.Pod, logger *sdklog.Logger) (*corev1.Pod, int) {
+	if probe.secretName != "" && !r.roleProbeSecrets {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "RoleProbeSecretsDisabled",
+			fmt.Sprintf("%s is set but the controller runs without --enable-role-probe-secrets. Leader Service %s will have no endpoints.",
+				AnnotationRoleProbeSecretService, r.getLeaderServiceName(svc)))
+		return nil, 0
+	}
+	if err := r.loadRoleProbeCredentials(ctx, svc, probe); err != nil {
+		logger.Info("Role probe Secret unavailable", sdklog.Operation("role_probe"),
+			sdklog.String("secret", probe.secretName), sdklog.String("error", err.Error()))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "RoleProbeSecretUnavailable",
+			fmt.Sprintf("Cannot read role probe Secret %s: %v. Leader Service %s will have no endpoints.",
+				probe.secretName, err, r.getLeaderServiceName(svc)))
+		return nil, 0
+	}
+	if probe.sendsPlaintextCredentials() && !probe.allowPlaintext {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "RoleProbePlaintextCredentials",
+			fmt.Sprintf("Role probe %s would send credentials without TLS; refusing (set %s: \"true\" to allow). Leader Service %s will have no endpoints.",
+				probe.url.String(), AnnotationRoleProbeAllowPlaintextService, r.getLeaderServiceName(svc)))
+		return nil, 0
+	}
+	primaries, failed := r.probePodRoles(ctx, svc, probe, candidates, logger)
+	if r.Metrics != nil {
+		r.Metrics.RecordRolePrimaryPods(svc.Namespace, svc.Name, len(primaries))
+	}
+	if len(failed) > 0 {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "RoleProbeFailed",
+			fmt.Sprintf("Role probe %s failed for pods: %s", probe.url.String(), strings.Join(failed, ", ")))
+	}
+
+	switch len(primaries) {
+	case 0:
+		if held, failures := r.holdLeaderOnProbeFailure(probe, currentLeader, last, candidates, failed, logger); held != nil {
+			return held, failures
+		}
+		logger.Info("No pod reports the primary role", sdklog.Operation("role_probe"), sdklog.Int("candidates", len(candidates)))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "NoPrimaryPod",
+			fmt.Sprintf("No eligible pod reports the primary role via %s. Leader Service %s will have no endpoints.",
+				probe.url.String(), r.getLeaderServiceName(svc)))
+		return nil, 0
+	case 1:
+		logger.Debug("Primary selected by role probe", sdklog.String("pod", primaries[0].Name))
+		return primaries[0], 0
+	default:
+		names := make([]string, 0, len(primaries))
+		for _, pod := range primaries {
+			names = append(names, pod.Name)
+		}
+		logger.Info("Several pods report the primary role", sdklog.Operation("role_probe"), sdklog.String("pods", strings.Join(names, ",")))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "MultiplePrimaryPods",
+			fmt.Sprintf("Pods %s all report the primary role via %s. Leader Service %s will have no endpoints until exactly one does.",
+				strings.Join(names, ", "), probe.url.String(), r.getLeaderServiceName(svc)))
+		return nil, 0
+	}
+}
+
+// holdLeaderOnProbeFailure returns the current leader and its consecutive failure count when its
+// probe failed and the failure threshold is not reached yet, or nil. Only the published leader
+// (and, if this controller probed before, the one its last round selected) is held, so a failing
+// pod is never promoted.
+func (r *ServiceDirectorReconciler) holdLeaderOnProbeFailure(probe *roleProbe, currentLeader *corev1.Pod, last *roleProbeRound, candidates []corev1.Pod, failed []string, logger *sdklog.Logger) (*corev1.Pod, int) {
+	if currentLeader == nil {
+		return nil, 0
+	}
+	failures := 1
+	if last != nil {
+		if last.leader != currentLeader.UID {
+			return nil, 0
+		}
+		failures = last.leaderFailures + 1
+	}
+	held := findPodByUID(candidates, currentLeader.UID)
+	if held == nil || !slices.Contains(failed, held.Name) || failures >= probe.failureThreshold {
+		return nil, 0
+	}
+	logger.Info("Keeping leader despite failed role probe",
+		sdklog.Operation("role_probe"),
+		sdklog.String("pod", held.Name),
+		sdklog.Int("failures", failures),
+		sdklog.Int("threshold", probe.failureThreshold))
+	return held, failures
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/url"
+
+	"github.com/go-sql-driver/mysql"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultMySQLUser = "root"
+
+	mysqlRoleQuery = "SELECT @@global.read_only"
+)
+
+// mysqlDriverLogger silences the driver's own logging; probe errors are returned and reported instead
+var mysqlDriverLogger = log.New(io.Discard, "", 0)
+
+// mysqlRoleProber treats a pod as primary when @@read_only is 0
+type mysqlRoleProber struct{}
+
+func (p *mysqlRoleProber) isPrimary(ctx context.Context, probe *roleProbe, pod *corev1.Pod) (bool, error) {
+	connector, err := mysql.NewConnector(mysqlConfig(probe, pod))
+	if err != nil {
+		return false, err
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var readOnly string
+	if err := db.QueryRowContext(ctx, mysqlRoleQuery).Scan(&readOnly); err != nil {
+		return false, err
+	}
+	switch readOnly {
+	case "0", "OFF":
+		return true, nil
+	case "1", "ON":
+		return false, nil
+	}
+	return false, fmt.Errorf("mysql query %q returned %q", mysqlRoleQuery, readOnly)
+}
+
+// mysqlUsesTLS reports whether a mysql:// role probe URL asks for TLS ("?tls=true"). Like https://
+// probes, the certificate is not verified because pods are addressed by IP.
+func mysqlUsesTLS(u *url.URL) bool {
+	return u.Query().Get("tls") == "true"
+}
+
+// mysqlConfig builds the driver config for one pod. Without TLS, caching_sha2_password full
+// authentication fetches the server's RSA key over the same unauthenticated connection, so a
+// password is only sent without TLS when plaintext credentials are allowed (see
+// sendsPlaintextCredentials).
+func mysqlConfig(probe *roleProbe, pod *corev1.Pod) *mysql.Config {
+	cfg := mysql.NewConfig()
+	cfg.User = probe.username
+	if cfg.User == "" {
+		cfg.User = defaultMySQLUser
+	}
+	cfg.Passwd = probe.password
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(pod.Status.PodIP, roleProbePort(probe.url))
+	cfg.Timeout = probe.timeout
+	cfg.ReadTimeout = probe.timeout
+	cfg.WriteTimeout = probe.timeout
+	cfg.Logger = mysqlDriverLogger
+	if mysqlUsesTLS(probe.url) {
+		cfg.TLS = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // probes address pods by IP, as kubelet does
+	}
+	return cfg
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // protocol-defined
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+)
+
+// fakeMySQL is an in-process MySQL server answering the role probe: handshake v10 with
+// mysql_native_password and the @@global.read_only text-protocol query
+type fakeMySQL struct {
+	user     string
+	password string
+	readOnly string
+}
+
+// fakeMySQLConn frames MySQL packets (3-byte length, sequence number)
+type fakeMySQLConn struct {
+	conn net.Conn
+	seq  byte
+}
+
+func (c *fakeMySQLConn) read() ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(c.conn, header[:]); err != nil {
+		return nil, err
+	}
+	c.seq = header[3] + 1
+	payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	_, err := io.ReadFull(c.conn, payload)
+	return payload, err
+}
+
+func (c *fakeMySQLConn) write(payload []byte) {
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), c.seq}
+	c.seq++
+	_, _ = c.conn.Write(append(header, payload...))
+}
+
+// mysqlLenEncString encodes a short length-encoded string
+func mysqlLenEncString(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+func (f *fakeMySQL) serve(t *testing.T) string {
+	t.Helper()
+	return serveRoleProbeFake(t, func(conn net.Conn) {
+		mc := &fakeMySQLConn{conn: conn}
+		scramble := []byte("abcdefghij0123456789")
+		greeting := append([]byte{10}, "8.4.0\x00"...)
+		greeting = append(greeting, 1, 0, 0, 0)
+		greeting = append(greeting, scramble[:8]...)
+		greeting = append(greeting, 0)
+		greeting = binary.LittleEndian.AppendUint16(greeting, 0xf7ff) // capabilities (lower), no TLS
+		greeting = append(greeting, 45, 2, 0)                         // utf8mb4, autocommit
+		greeting = binary.LittleEndian.AppendUint16(greeting, 0x000f) // capabilities (upper): plugin auth
+		greeting = append(greeting, 21)
+		greeting = append(greeting, make([]byte, 10)...)
+		greeting = append(append(greeting, scramble[8:]...), 0)
+		greeting = append(greeting, "mysql_native_password\x00"...)
+		mc.write(greeting)
+
+		resp, err := mc.read()
+		if err != nil || len(resp) < 33 {
+			return
+		}
+		user, rest, _ := bytes.Cut(resp[32:], []byte{0})
+		var authResponse []byte
+		if len(rest) > 0 && len(rest) > int(rest[0]) {
+			authResponse = rest[1 : 1+int(rest[0])]
+		}
+		if string(user) != f.user || !bytes.Equal(authResponse, fakeMySQLNativePassword(f.password, scramble)) {
+			mc.write(append([]byte{0xff, 0x15, 0x04, '#', '2', '8', '0', '0', '0'}, "Access denied for user"...))
+			return
+		}
+		mc.write([]byte{0x00, 0, 0, 2, 0, 0, 0})
+
+		mc.seq = 0
+		query, err := mc.read()
+		if err != nil || string(query) != "\x03"+mysqlRoleQuery {
+			mc.write(append([]byte{0xff, 0x28, 0x04, '#', '4', '2', '0', '0', '0'}, "unexpected query"...))
+			return
+		}
+		column := append(mysqlLenEncString("def"), 0, 0, 0)
+		column = append(column, mysqlLenEncString("@@global.read_only")...)
+		column = append(column, 0, 0x0c, 0x3f, 0, 1, 0, 0, 0, 0x08, 0x80, 0, 0, 0, 0)
+		mc.write([]byte{1})
+		mc.write(column)
+		mc.write([]byte{0xfe, 0, 0, 2, 0})
+		mc.write(mysqlLenEncString(f.readOnly))
+		mc.write([]byte{0xfe, 0, 0, 2, 0})
+		_, _ = mc.read() // COM_QUIT
+	})
+}
+
+// fakeMySQLNativePassword computes the mysql_native_password auth response
+func fakeMySQLNativePassword(password string, scramble []byte) []byte {
+	if password == "" {
+		return nil
+	}
+	stage1 := sha1.Sum([]byte(password))                                  //nolint:gosec // protocol-defined
+	stage2 := sha1.Sum(stage1[:])                                         //nolint:gosec // protocol-defined
+	hash := sha1.Sum(append(append([]byte{}, scramble...), stage2[:]...)) //nolint:gosec // protocol-defined
+	for i := range hash {
+		hash[i] ^= stage1[i]
+	}
+	return hash[:]
+}
+
+func TestMySQLRoleProber(t *testing.T) {
+	tests := []struct {
+		name          string
+		server        fakeMySQL
+		username      string
+		password      string
+		expectPrimary bool
+		expectError   bool
+	}{
+		{name: "writable with native password", server: fakeMySQL{user: "monitor", password: "s3cret", readOnly: "0"}, username: "monitor", password: "s3cret", expectPrimary: true},
+		{name: "read only replica", server: fakeMySQL{user: "monitor", password: "s3cret", readOnly: "1"}, username: "monitor", password: "s3cret"},
+		{name: "default user without password", server: fakeMySQL{user: "root", readOnly: "0"}, expectPrimary: true},
+		{name: "wrong password", server: fakeMySQL{user: "monitor", password: "s3cret", readOnly: "0"}, username: "monitor", password: "wrong", expectError: true},
+		{name: "unexpected value", server: fakeMySQL{user: "root", readOnly: "maybe"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port := tt.server.serve(t)
+			probe, pod := loopbackRoleProbe(t, "mysql://:"+port, tt.username, tt.password)
+
+			primary, err := runRoleProber(&mysqlRoleProber{}, probe, pod)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("isPrimary() error = %v, expectError %v", err, tt.expectError)
+			}
+			if primary != tt.expectPrimary {
+				t.Errorf("isPrimary() = %v, expected %v", primary, tt.expectPrimary)
+			}
+		})
+	}
+}
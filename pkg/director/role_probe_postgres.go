@@ -0,0 +1,113 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultPostgresUser     = "postgres"
+	defaultPostgresDatabase = "postgres"
+	// defaultPostgresSSLMode uses TLS when the server offers it, like libpq
+	defaultPostgresSSLMode = "prefer"
+
+	postgresRoleQuery = "SELECT pg_is_in_recovery()"
+)
+
+// postgresTLSSSLModes are the sslmode values that never fall back to a plaintext connection
+var postgresTLSSSLModes = map[string]bool{"require": true, "verify-ca": true, "verify-full": true}
+
+// postgresSSLModes are the sslmode values accepted in a postgres:// role probe URL
+var postgresSSLModes = map[string]bool{"disable": true, "allow": true, "prefer": true, "require": true, "verify-ca": true, "verify-full": true}
+
+// postgresRoleProber treats a pod as primary when pg_is_in_recovery() is false
+type postgresRoleProber struct{}
+
+func (p *postgresRoleProber) isPrimary(ctx context.Context, probe *roleProbe, pod *corev1.Pod) (bool, error) {
+	config, err := postgresConnConfig(probe, pod)
+	if err != nil {
+		return false, err
+	}
+	conn, err := pgconn.ConnectConfig(ctx, config)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(ctx)
+
+	results, err := conn.Exec(ctx, postgresRoleQuery).ReadAll()
+	if err != nil {
+		return false, err
+	}
+	if len(results) != 1 || len(results[0].Rows) != 1 || len(results[0].Rows[0]) != 1 {
+		return false, fmt.Errorf("postgres query %q returned no value", postgresRoleQuery)
+	}
+	switch value := string(results[0].Rows[0][0]); value {
+	case "f":
+		return true, nil
+	case "t":
+		return false, nil
+	default:
+		return false, fmt.Errorf("postgres query %q returned %q", postgresRoleQuery, value)
+	}
+}
+
+// postgresSSLMode returns the sslmode of a postgres:// role probe URL (default prefer)
+func postgresSSLMode(u *url.URL) string {
+	if mode := u.Query().Get("sslmode"); mode != "" {
+		return mode
+	}
+	return defaultPostgresSSLMode
+}
+
+// postgresConnConfig builds the connection config for one pod. Only sslmode is taken from the probe
+// URL, so the annotation cannot make the controller read local files (passfile, sslrootcert, ...).
+// Unless TLS is required or plaintext credentials are allowed, cleartext password authentication is
+// refused; md5 and SCRAM-SHA-256 never reveal the password.
+func postgresConnConfig(probe *roleProbe, pod *corev1.Pod) (*pgconn.Config, error) {
+	user := probe.username
+	if user == "" {
+		user = defaultPostgresUser
+	}
+	database := probe.url.Path
+	if database == "" || database == "/" {
+		database = "/" + defaultPostgresDatabase
+	}
+	sslMode := postgresSSLMode(probe.url)
+	connString := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(user, probe.password),
+		Host:     net.JoinHostPort(pod.Status.PodIP, roleProbePort(probe.url)),
+		Path:     database,
+		RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+	}
+	config, err := pgconn.ParseConfig(connString.String())
+	if err != nil {
+		return nil, err
+	}
+	config.ConnectTimeout = probe.timeout
+	if !postgresTLSSSLModes[sslMode] && !probe.allowPlaintext {
+		config.RequireAuth = "!password"
+	}
+	return config, nil
+}
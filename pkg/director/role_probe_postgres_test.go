@@ -0,0 +1,154 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"crypto/md5" //nolint:gosec // protocol-defined
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// fakePostgres is an in-process PostgreSQL backend answering the role probe: startup, one
+// authentication method and the pg_is_in_recovery() query
+type fakePostgres struct {
+	auth       string // "trust", "cleartext" or "md5"
+	user       string
+	password   string
+	database   string
+	inRecovery bool
+}
+
+func (f *fakePostgres) serve(t *testing.T) string {
+	t.Helper()
+	return serveRoleProbeFake(t, func(conn net.Conn) {
+		backend := pgproto3.NewBackend(conn, conn)
+		fail := func(code, message string) {
+			backend.Send(&pgproto3.ErrorResponse{Severity: "FATAL", Code: code, Message: message})
+			_ = backend.Flush()
+		}
+
+		var startup *pgproto3.StartupMessage
+		for startup == nil {
+			msg, err := backend.ReceiveStartupMessage()
+			if err != nil {
+				return
+			}
+			switch msg := msg.(type) {
+			case *pgproto3.SSLRequest, *pgproto3.GSSEncRequest:
+				if _, err := conn.Write([]byte{'N'}); err != nil {
+					return
+				}
+			case *pgproto3.StartupMessage:
+				startup = msg
+			}
+		}
+		if startup.Parameters["user"] != f.user || startup.Parameters["database"] != f.database {
+			fail("3D000", "unknown user or database")
+			return
+		}
+		if !f.authenticate(backend) {
+			fail("28P01", "password authentication failed for user \""+f.user+"\"")
+			return
+		}
+		backend.Send(&pgproto3.AuthenticationOk{})
+		backend.Send(&pgproto3.ParameterStatus{Name: "server_version", Value: "17.0"})
+		backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		if err := backend.Flush(); err != nil {
+			return
+		}
+
+		msg, err := backend.Receive()
+		if query, ok := msg.(*pgproto3.Query); err != nil || !ok || query.String != postgresRoleQuery {
+			fail("42601", "unexpected query")
+			return
+		}
+		value := "f"
+		if f.inRecovery {
+			value = "t"
+		}
+		backend.Send(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{{Name: []byte("pg_is_in_recovery"), DataTypeOID: 16, DataTypeSize: 1, TypeModifier: -1}}})
+		backend.Send(&pgproto3.DataRow{Values: [][]byte{[]byte(value)}})
+		backend.Send(&pgproto3.CommandComplete{CommandTag: []byte("SELECT 1")})
+		backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		_ = backend.Flush()
+	})
+}
+
+// authenticate runs the configured authentication exchange and reports whether the password matched
+func (f *fakePostgres) authenticate(backend *pgproto3.Backend) bool {
+	var expected string
+	switch f.auth {
+	case "cleartext":
+		backend.Send(&pgproto3.AuthenticationCleartextPassword{})
+		expected = f.password
+	case "md5":
+		salt := [4]byte{1, 2, 3, 4}
+		backend.Send(&pgproto3.AuthenticationMD5Password{Salt: salt})
+		inner := md5.Sum([]byte(f.password + f.user))                              //nolint:gosec // protocol-defined
+		outer := md5.Sum(append([]byte(hex.EncodeToString(inner[:])), salt[:]...)) //nolint:gosec // protocol-defined
+		expected = "md5" + hex.EncodeToString(outer[:])
+	default:
+		return true
+	}
+	if err := backend.Flush(); err != nil {
+		return false
+	}
+	msg, err := backend.Receive()
+	password, ok := msg.(*pgproto3.PasswordMessage)
+	return err == nil && ok && password.Password == expected
+}
+
+func TestPostgresRoleProber(t *testing.T) {
+	tests := []struct {
+		name          string
+		server        fakePostgres
+		path          string
+		username      string
+		password      string
+		allowPlain    bool
+		expectPrimary bool
+		expectError   bool
+	}{
+		{name: "primary with trust auth", server: fakePostgres{auth: "trust", user: "postgres", database: "postgres"}, expectPrimary: true},
+		{name: "standby", server: fakePostgres{auth: "trust", user: "postgres", database: "postgres", inRecovery: true}},
+		{name: "md5 auth", server: fakePostgres{auth: "md5", user: "monitor", password: "s3cret", database: "app"}, path: "/app", username: "monitor", password: "s3cret", expectPrimary: true},
+		{name: "md5 wrong password", server: fakePostgres{auth: "md5", user: "monitor", password: "s3cret", database: "postgres"}, username: "monitor", password: "wrong", expectError: true},
+		{name: "cleartext auth refused", server: fakePostgres{auth: "cleartext", user: "monitor", password: "s3cret", database: "postgres"}, username: "monitor", password: "s3cret", expectError: true},
+		{name: "cleartext auth allowed", server: fakePostgres{auth: "cleartext", user: "monitor", password: "s3cret", database: "postgres"}, username: "monitor", password: "s3cret", allowPlain: true, expectPrimary: true},
+		{name: "unknown database", server: fakePostgres{auth: "trust", user: "postgres", database: "postgres"}, path: "/other", expectError: true},
+		{name: "TLS required but not offered", server: fakePostgres{auth: "trust", user: "postgres", database: "postgres"}, path: "/postgres?sslmode=require", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port := tt.server.serve(t)
+			probe, pod := loopbackRoleProbe(t, "postgres://:"+port+tt.path, tt.username, tt.password)
+			probe.allowPlaintext = tt.allowPlain
+
+			primary, err := runRoleProber(&postgresRoleProber{}, probe, pod)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("isPrimary() error = %v, expectError %v", err, tt.expectError)
+			}
+			if primary != tt.expectPrimary {
+				t.Errorf("isPrimary() = %v, expected %v", primary, tt.expectPrimary)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxRedisReplySize bounds a bulk reply (INFO replication is a few hundred bytes)
+const maxRedisReplySize = 1 << 20
+
+// redisRoleProber treats a pod as primary when INFO replication reports role:master
+type redisRoleProber struct{}
+
+func (p *redisRoleProber) isPrimary(ctx context.Context, probe *roleProbe, pod *corev1.Pod) (bool, error) {
+	conn, err := dialRoleProbe(ctx, probe, pod)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+	if probe.password != "" {
+		args := []string{"AUTH", probe.password}
+		if probe.username != "" {
+			args = []string{"AUTH", probe.username, probe.password}
+		}
+		if _, err := redisCommand(rw, args...); err != nil {
+			return false, fmt.Errorf("redis AUTH: %w", err)
+		}
+	}
+	info, err := redisCommand(rw, "INFO", "replication")
+	if err != nil {
+		return false, fmt.Errorf("redis INFO replication: %w", err)
+	}
+	role, err := redisInfoRole(info)
+	if err != nil {
+		return false, err
+	}
+	return role == "master", nil
+}
+
+// redisCommand sends a command as a RESP array and returns the string reply
+func redisCommand(rw *bufio.ReadWriter, args ...string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := rw.WriteString(b.String()); err != nil {
+		return "", err
+	}
+	if err := rw.Flush(); err != nil {
+		return "", err
+	}
+	return readRedisReply(rw.Reader)
+}
+
+// readRedisReply reads a simple string, error, integer or bulk string reply
+func readRedisReply(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", errors.New("empty redis reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("redis error: %s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < -1 || size > maxRedisReplySize {
+			return "", fmt.Errorf("invalid redis bulk length %q", line[1:])
+		}
+		if size == -1 {
+			return "", nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:size]), nil
+	default:
+		return "", fmt.Errorf("unexpected redis reply %q", line)
+	}
+}
+
+// redisInfoRole returns the role field of an INFO replication reply
+func redisInfoRole(info string) (string, error) {
+	for _, line := range strings.Split(info, "\n") {
+		if role, ok := strings.CutPrefix(strings.TrimSpace(line), "role:"); ok {
+			return role, nil
+		}
+	}
+	return "", errors.New("redis INFO replication reply has no role field")
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newFakeRedis starts an in-process Redis speaking enough RESP for AUTH and INFO replication
+func newFakeRedis(t *testing.T, role, password string) string {
+	t.Helper()
+	return serveRoleProbeFake(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		authenticated := password == ""
+		for {
+			args, err := readFakeRedisCommand(r)
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.EqualFold(args[0], "AUTH"):
+				if args[len(args)-1] != password {
+					fmt.Fprint(conn, "-WRONGPASS invalid username-password pair\r\n")
+					continue
+				}
+				authenticated = true
+				fmt.Fprint(conn, "+OK\r\n")
+			case !authenticated:
+				fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+			case strings.EqualFold(args[0], "INFO"):
+				info := "# Replication\r\nrole:" + role + "\r\nconnected_slaves:0\r\n"
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(info), info)
+			default:
+				fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+			}
+		}
+	})
+}
+
+// readFakeRedisCommand reads one RESP array of bulk strings
+func readFakeRedisCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf("invalid command %q", line)
+	}
+	args := make([]string, count)
+	for i := range args {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisRoleProber(t *testing.T) {
+	tests := []struct {
+		name          string
+		role          string
+		password      string
+		probePassword string
+		expectPrimary bool
+		expectError   bool
+	}{
+		{name: "master", role: "master", expectPrimary: true},
+		{name: "replica", role: "slave"},
+		{name: "authenticated master", role: "master", password: "s3cret", probePassword: "s3cret", expectPrimary: true},
+		{name: "wrong password", role: "master", password: "s3cret", probePassword: "wrong", expectError: true},
+		{name: "missing password", role: "master", password: "s3cret", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port := newFakeRedis(t, tt.role, tt.password)
+			probe, pod := loopbackRoleProbe(t, "redis://:"+port, "", tt.probePassword)
+
+			primary, err := runRoleProber(&redisRoleProber{}, probe, pod)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("isPrimary() error = %v, expectError %v", err, tt.expectError)
+			}
+			if primary != tt.expectPrimary {
+				t.Errorf("isPrimary() = %v, expected %v", primary, tt.expectPrimary)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderByRoleProbe_Secret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	port := newFakeRedis(t, "master", "s3cret")
+	pod := newReadyPod("pod-a", 0)
+	pod.Status.PodIP = "127.0.0.1"
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redis-credentials",
+			Namespace: "default",
+			Labels:    map[string]string{LabelRoleProbeCredentials: "true"},
+		},
+		Data: map[string][]byte{RoleProbeSecretPasswordKey: []byte("s3cret")},
+	}
+	unlabelled := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-credentials", Namespace: "default"},
+		Data:       map[string][]byte{RoleProbeSecretPasswordKey: []byte("s3cret")},
+	}
+
+	tests := []struct {
+		name            string
+		secretName      string
+		allowPlaintext  bool
+		secretsDisabled bool
+		expectedPod     string
+		expectEvent     string
+	}{
+		{name: "credentials from Secret", secretName: "redis-credentials", allowPlaintext: true, expectedPod: "pod-a"},
+		{name: "missing Secret fails closed", secretName: "absent", allowPlaintext: true, expectEvent: "RoleProbeSecretUnavailable"},
+		{name: "unlabelled Secret fails closed", secretName: "app-credentials", allowPlaintext: true, expectEvent: "RoleProbeSecretUnavailable"},
+		{name: "Secrets disabled fails closed", secretName: "redis-credentials", allowPlaintext: true, secretsDisabled: true, expectEvent: "RoleProbeSecretsDisabled"},
+		{name: "plaintext AUTH refused", secretName: "redis-credentials", expectEvent: "RoleProbePlaintextCredentials"},
+		{name: "no Secret configured", expectEvent: "RoleProbeFailed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-service",
+				Namespace: "default",
+				Annotations: map[string]string{
+					AnnotationRoleProbeService:       "redis://:" + port,
+					AnnotationRoleProbeSecretService: tt.secretName,
+				},
+			}}
+			if tt.allowPlaintext {
+				svc.Annotations[AnnotationRoleProbeAllowPlaintextService] = "true"
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, unlabelled).Build(),
+				Scheme:           scheme,
+				Recorder:         eventRecorder,
+				Metrics:          metrics.NewRecorder(),
+				roleProbeSecrets: !tt.secretsDisabled,
+			}
+
+			probe, _ := r.getRoleProbe(svc)
+			logger := packageLogger.WithContext(context.Background())
+			leader, _ := r.selectLeaderByRoleProbe(context.Background(), svc, probe, nil, []corev1.Pod{pod}, logger)
+			if tt.expectedPod == "" {
+				if leader != nil {
+					t.Fatalf("selectLeaderByRoleProbe() = %s, expected no leader", leader.Name)
+				}
+			} else if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderByRoleProbe() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
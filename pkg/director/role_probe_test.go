@@ -0,0 +1,394 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeRoleServer stands in for every pod's role endpoint: requests are routed to it regardless of
+// the pod IP, and it answers by the pod IP in the Host header
+type fakeRoleServer struct {
+	mu        sync.Mutex
+	primaries map[string]bool // pod IP -> reports primary
+	slow      map[string]bool // pod IP -> never answers in time
+	server    *httptest.Server
+}
+
+func newFakeRoleServer(t *testing.T) *fakeRoleServer {
+	t.Helper()
+	s := &fakeRoleServer{primaries: map[string]bool{}, slow: map[string]bool{}}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		host, _, _ := net.SplitHostPort(req.Host)
+		s.mu.Lock()
+		primary, slow := s.primaries[host], s.slow[host]
+		s.mu.Unlock()
+		if slow {
+			select {
+			case <-req.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		if req.URL.Path != "/primary" || !primary {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *fakeRoleServer) setPrimaries(ips ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.primaries = map[string]bool{}
+	for _, ip := range ips {
+		s.primaries[ip] = true
+	}
+}
+
+// client returns an HTTP client that dials the fake server for every pod IP
+func (s *fakeRoleServer) client() *http.Client {
+	addr := s.server.Listener.Addr().String()
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+// serveRoleProbeFake serves each connection to a loopback listener with handle and returns the port
+func serveRoleProbeFake(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return port
+}
+
+// loopbackRoleProbe parses a role probe annotation and returns it with a pod addressed at 127.0.0.1
+func loopbackRoleProbe(t *testing.T, rawURL, username, password string) (*roleProbe, *corev1.Pod) {
+	t.Helper()
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Annotations: map[string]string{
+		AnnotationRoleProbeService: rawURL,
+	}}}
+	probe, err := parseRoleProbe(svc)
+	if err != nil {
+		t.Fatalf("parseRoleProbe() error = %v", err)
+	}
+	probe.timeout = 2 * time.Second
+	probe.username, probe.password = username, password
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a"}, Status: corev1.PodStatus{PodIP: "127.0.0.1"}}
+	return probe, pod
+}
+
+// runRoleProber runs a prober with the probe timeout
+func runRoleProber(prober roleProber, probe *roleProbe, pod *corev1.Pod) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probe.timeout)
+	defer cancel()
+	return prober.isPrimary(ctx, probe, pod)
+}
+
+func TestParseRoleProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectError bool
+		expectPort  string
+	}{
+		{name: "http with port", annotations: map[string]string{AnnotationRoleProbeService: "http://:8008/primary"}, expectPort: "8008"},
+		{name: "https default port", annotations: map[string]string{AnnotationRoleProbeService: "https:///primary"}, expectPort: "443"},
+		{name: "redis default port", annotations: map[string]string{AnnotationRoleProbeService: "redis://"}, expectPort: "6379"},
+		{name: "postgres with database", annotations: map[string]string{AnnotationRoleProbeService: "postgres://:5433/app"}, expectPort: "5433"},
+		{name: "mysql default port", annotations: map[string]string{AnnotationRoleProbeService: "mysql://"}, expectPort: "3306"},
+		{name: "postgres sslmode", annotations: map[string]string{AnnotationRoleProbeService: "postgres://:5432/app?sslmode=require"}, expectPort: "5432"},
+		{name: "postgres unsupported sslmode", annotations: map[string]string{AnnotationRoleProbeService: "postgres://:5432/app?sslmode=maybe"}, expectError: true},
+		{name: "unsupported scheme", annotations: map[string]string{AnnotationRoleProbeService: "ftp://:21/"}, expectError: true},
+		{name: "credentials not allowed in URL", annotations: map[string]string{AnnotationRoleProbeService: "postgres://postgres:secret@:5432/"}, expectError: true},
+		{name: "host not allowed", annotations: map[string]string{AnnotationRoleProbeService: "http://db.example.com:8008/primary"}, expectError: true},
+		{name: "invalid timeout", annotations: map[string]string{AnnotationRoleProbeService: "http://:8008/primary", AnnotationRoleProbeTimeoutService: "soon"}, expectError: true},
+		{name: "invalid interval", annotations: map[string]string{AnnotationRoleProbeService: "http://:8008/primary", AnnotationRoleProbeIntervalService: "-1s"}, expectError: true},
+		{name: "invalid failure threshold", annotations: map[string]string{AnnotationRoleProbeService: "http://:8008/primary", AnnotationRoleProbeFailureThresholdService: "0"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Annotations: tt.annotations}}
+			probe, err := parseRoleProbe(svc)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseRoleProbe() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err == nil && roleProbePort(probe.url) != tt.expectPort {
+				t.Errorf("roleProbePort() = %s, expected %s", roleProbePort(probe.url), tt.expectPort)
+			}
+		})
+	}
+}
+
+func TestRoleProbeSendsPlaintextCredentials(t *testing.T) {
+	tests := []struct {
+		url      string
+		username string
+		password string
+		expected bool
+	}{
+		{url: "http://:8008/primary", expected: false},
+		{url: "http://:8008/primary", username: "monitor", password: "s3cret", expected: true},
+		{url: "https://:8008/primary", username: "monitor", password: "s3cret", expected: false},
+		{url: "redis://:6379", password: "s3cret", expected: true},
+		{url: "postgres://:5432/app", username: "monitor", password: "s3cret", expected: false},
+		{url: "mysql://:3306", username: "monitor", password: "s3cret", expected: true},
+		{url: "mysql://:3306?tls=true", username: "monitor", password: "s3cret", expected: false},
+		{url: "mysql://:3306", username: "monitor", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url+"/"+tt.username+"/"+tt.password, func(t *testing.T) {
+			probe, _ := loopbackRoleProbe(t, tt.url, tt.username, tt.password)
+			if got := probe.sendsPlaintextCredentials(); got != tt.expected {
+				t.Errorf("sendsPlaintextCredentials() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderByRoleProbe(t *testing.T) {
+	podOld := newReadyPod("pod-old", time.Hour)
+	podOld.Status.PodIP = "10.0.0.1"
+	podNew := newReadyPod("pod-new", time.Minute)
+	podNew.Status.PodIP = "10.0.0.2"
+
+	tests := []struct {
+		name        string
+		primaries   []string
+		slow        []string
+		expectedPod string
+		expectEvent string
+	}{
+		{name: "single primary wins over strategy order", primaries: []string{"10.0.0.2"}, expectedPod: "pod-new"},
+		{name: "no primary", expectEvent: "NoPrimaryPod"},
+		{name: "several primaries", primaries: []string{"10.0.0.1", "10.0.0.2"}, expectEvent: "MultiplePrimaryPods"},
+		{name: "probe timeout is reported", primaries: []string{"10.0.0.1"}, slow: []string{"10.0.0.2"}, expectedPod: "pod-old", expectEvent: "RoleProbeFailed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeRoleServer(t)
+			server.setPrimaries(tt.primaries...)
+			for _, ip := range tt.slow {
+				server.slow[ip] = true
+			}
+
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-service",
+				Namespace: "default",
+				Annotations: map[string]string{
+					AnnotationRoleProbeService:        "http://:8008/primary",
+					AnnotationRoleProbeTimeoutService: "100ms",
+				},
+			}}
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Recorder:            eventRecorder,
+				Metrics:             metrics.NewRecorder(),
+				roleProbeHTTPClient: server.client(),
+			}
+
+			probe, configured := r.getRoleProbe(svc)
+			if !configured || probe == nil {
+				t.Fatal("expected role probe to be configured")
+			}
+			logger := packageLogger.WithContext(context.Background())
+			leader, _ := r.selectLeaderByRoleProbe(context.Background(), svc, probe, nil, []corev1.Pod{podOld, podNew}, logger)
+			if tt.expectedPod == "" {
+				if leader != nil {
+					t.Fatalf("selectLeaderByRoleProbe() = %s, expected no leader", leader.Name)
+				}
+			} else if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderByRoleProbe() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if tt.expectEvent != "" && strings.Contains(event, tt.expectEvent) {
+					gotEvent = true
+				}
+			}
+			if tt.expectEvent != "" && !gotEvent {
+				t.Errorf("expected %s event", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_RoleProbe(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:   "true",
+				AnnotationRoleProbeService: "http://:8008/primary",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "postgres", Port: 5432, TargetPort: intstr.FromInt32(5432), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 5432)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 5432)
+
+	server := newFakeRoleServer(t)
+	server.setPrimaries("10.0.0.2")
+	r := &ServiceDirectorReconciler{
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:              scheme,
+		Recorder:            record.NewFakeRecorder(50),
+		Metrics:             metrics.NewRecorder(),
+		roleProbeHTTPClient: server.client(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != defaultRoleProbeInterval {
+		t.Errorf("RequeueAfter = %v, expected %v", result.RequeueAfter, defaultRoleProbeInterval)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints = %v, expected [pod-b]", got)
+	}
+
+	// The database promotes another pod - zen-lead follows at the next probe round, stickiness does not apply
+	server.setPrimaries("10.0.0.1")
+	r.forgetRoleProbeRound("default/my-service")
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+		t.Fatalf("leader endpoints after promotion = %v, expected [pod-a]", got)
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderByRoleProbe_FailureThreshold(t *testing.T) {
+	podA := newReadyPod("pod-a", time.Hour)
+	podA.UID = "uid-a"
+	podA.Status.PodIP = "10.0.0.1"
+	podB := newReadyPod("pod-b", time.Minute)
+	podB.UID = "uid-b"
+	podB.Status.PodIP = "10.0.0.2"
+	candidates := []corev1.Pod{podA, podB}
+
+	server := newFakeRoleServer(t)
+	server.setPrimaries("10.0.0.1")
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-service",
+		Namespace: "default",
+		Annotations: map[string]string{
+			AnnotationRoleProbeService:                 "http://:8008/primary",
+			AnnotationRoleProbeTimeoutService:          "100ms",
+			AnnotationRoleProbeFailureThresholdService: "2",
+		},
+	}}
+	r := &ServiceDirectorReconciler{
+		Recorder:            record.NewFakeRecorder(50),
+		Metrics:             metrics.NewRecorder(),
+		roleProbeHTTPClient: server.client(),
+	}
+	probe, _ := r.getRoleProbe(svc)
+	logger := packageLogger.WithContext(context.Background())
+	ctx := context.Background()
+
+	leader, next := r.selectLeaderByRoleProbe(ctx, svc, probe, nil, candidates, logger)
+	if leader == nil || leader.Name != "pod-a" {
+		t.Fatalf("selectLeaderByRoleProbe() = %v, expected pod-a", leader)
+	}
+	if next != defaultRoleProbeInterval {
+		t.Errorf("next probe in %v, expected %v", next, defaultRoleProbeInterval)
+	}
+
+	// Within the interval the last round is reused - no new probe sees the role change
+	server.setPrimaries("10.0.0.2")
+	if leader, _ = r.selectLeaderByRoleProbe(ctx, svc, probe, &podA, candidates, logger); leader == nil || leader.Name != "pod-a" {
+		t.Fatalf("selectLeaderByRoleProbe() within interval = %v, expected reused pod-a", leader)
+	}
+
+	// The leader's probe times out once: it is kept
+	server.setPrimaries()
+	server.mu.Lock()
+	server.slow["10.0.0.1"] = true
+	server.mu.Unlock()
+	expireRoleProbeRound(r, "default/my-service")
+	if leader, _ = r.selectLeaderByRoleProbe(ctx, svc, probe, &podA, candidates, logger); leader == nil || leader.Name != "pod-a" {
+		t.Fatalf("selectLeaderByRoleProbe() after one failure = %v, expected pod-a held", leader)
+	}
+
+	// The second consecutive failure reaches the threshold: no leader
+	expireRoleProbeRound(r, "default/my-service")
+	if leader, _ = r.selectLeaderByRoleProbe(ctx, svc, probe, &podA, candidates, logger); leader != nil {
+		t.Fatalf("selectLeaderByRoleProbe() after threshold = %s, expected no leader", leader.Name)
+	}
+}
+
+// expireRoleProbeRound makes the next reconcile probe again, keeping the failure count
+func expireRoleProbeRound(r *ServiceDirectorReconciler, serviceKey string) {
+	r.roleProbeRoundsMu.Lock()
+	defer r.roleProbeRoundsMu.Unlock()
+	r.roleProbeRounds[serviceKey].probedAt = time.Time{}
+}
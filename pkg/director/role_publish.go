@@ -0,0 +1,272 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationPublishRoleService opts a Service into publishing each pod's role onto the pod itself:
+	// "label" (zen-lead.io/role label), "condition" (zen-lead.io/leader pod condition) or "label,condition".
+	// Requires the controller to run with --enable-role-publishing and the role-publishing RBAC.
+	AnnotationPublishRoleService = "zen-lead.io/publish-role"
+
+	// LabelRole is the pod label published in "label" mode (leader or follower)
+	LabelRole = "zen-lead.io/role"
+	// LabelRoleSource names the source Service publishing a pod's role. A pod selected by several
+	// publishing Services is only published by the one that claimed it first.
+	LabelRoleSource = "zen-lead.io/role-source"
+	// PodConditionLeader is the pod condition published in "condition" mode (True on leaders)
+	PodConditionLeader corev1.PodConditionType = "zen-lead.io/leader"
+
+	// Published roles
+	RoleLeader   = "leader"
+	RoleFollower = "follower"
+
+	// Publish modes
+	PublishRoleLabel     = "label"
+	PublishRoleCondition = "condition"
+)
+
+// SetRolePublishing enables zen-lead.io/publish-role. Off by default: zen-lead never mutates pods unless
+// the operator grants the extra RBAC and enables it (--enable-role-publishing).
+func (r *ServiceDirectorReconciler) SetRolePublishing(enabled bool) {
+	r.rolePublishing = enabled
+}
+
+// getPublishRoleModes parses zen-lead.io/publish-role into (label, condition)
+func getPublishRoleModes(svc *corev1.Service) (bool, bool, error) {
+	if svc.Annotations == nil {
+		return false, false, nil
+	}
+	val := strings.TrimSpace(svc.Annotations[AnnotationPublishRoleService])
+	if val == "" {
+		return false, false, nil
+	}
+	label, condition := false, false
+	for _, mode := range strings.Split(val, ",") {
+		switch strings.TrimSpace(mode) {
+		case PublishRoleLabel:
+			label = true
+		case PublishRoleCondition:
+			condition = true
+		default:
+			return false, false, fmt.Errorf("invalid %s %q (expected %q, %q or both)", AnnotationPublishRoleService, val, PublishRoleLabel, PublishRoleCondition)
+		}
+	}
+	return label, condition, nil
+}
+
+// publishPodRoles publishes leader/follower onto the Service's pods (zen-lead.io/publish-role). It runs after
+// the EndpointSlice write, and demotes former leaders before promoting new ones so that the label or
+// condition never names two leaders at once; if a demotion fails, nothing is promoted.
+// Roles published earlier are cleared from pods that left the selector and for modes no longer requested.
+func (r *ServiceDirectorReconciler) publishPodRoles(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, activePods []*corev1.Pod, logger *sdklog.Logger) error {
+	label, condition, err := getPublishRoleModes(svc)
+	if err != nil {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidPublishRole", fmt.Sprintf("%v: pod roles not published", err))
+		return nil
+	}
+	if !r.rolePublishing {
+		if label || condition {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "RolePublishingDisabled",
+				fmt.Sprintf("%s is set but the controller runs without --enable-role-publishing: pod roles not published", AnnotationPublishRoleService))
+		}
+		return nil
+	}
+
+	// Pods published earlier that are no longer selected (or every pod once publishing is turned off)
+	var keep map[types.UID]bool
+	if label || condition {
+		keep = make(map[types.UID]bool, len(pods))
+		for i := range pods {
+			keep[pods[i].UID] = true
+		}
+	}
+	if err := r.clearPodRoles(ctx, svc.Namespace, svc.Name, keep, logger); err != nil {
+		return err
+	}
+	if !label && !condition {
+		return nil
+	}
+
+	active := make(map[types.UID]bool, len(activePods))
+	for _, pod := range activePods {
+		active[pod.UID] = true
+	}
+
+	// Demote first, then promote
+	var conflicts []string
+	for _, promote := range []bool{false, true} {
+		for i := range pods {
+			pod := &pods[i]
+			if active[pod.UID] != promote || pod.DeletionTimestamp != nil {
+				continue
+			}
+			// The role key is shared: never take over a pod another Service publishes
+			if owner := pod.Labels[LabelRoleSource]; owner != "" && owner != svc.Name {
+				conflicts = append(conflicts, fmt.Sprintf("%s (%s)", pod.Name, owner))
+				continue
+			}
+			role := RoleFollower
+			if promote {
+				role = RoleLeader
+			}
+			labelRole := ""
+			if label {
+				labelRole = role
+			}
+			if err := r.patchPodRoleLabels(ctx, svc.Name, pod, labelRole, svc.Name); err != nil {
+				return err
+			}
+			if condition {
+				err = r.publishRoleCondition(ctx, svc, pod, role)
+			} else {
+				err = r.clearRoleCondition(ctx, svc.Name, pod)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "PodRoleConflict",
+			fmt.Sprintf("Roles of pods already published by another Service were not published: %s", strings.Join(conflicts, ", ")))
+	}
+	logger.Debug("Published pod roles", sdklog.Int("leaders", len(activePods)), sdklog.Int("pods", len(pods)))
+	return nil
+}
+
+// clearPodRoles removes the role label, the role source label and the leader condition from pods whose role
+// was published for the source Service, except the pods in keep (nil clears every pod)
+func (r *ServiceDirectorReconciler) clearPodRoles(ctx context.Context, namespace, source string, keep map[types.UID]bool, logger *sdklog.Logger) error {
+	podList := &corev1.PodList{}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabels{LabelRoleSource: source})
+	}, r.Metrics, namespace, source, "list_pods_role_source"); err != nil {
+		return fmt.Errorf("failed to list pods with published roles for %s/%s: %w", namespace, source, err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if keep[pod.UID] {
+			continue
+		}
+		if err := r.clearRoleCondition(ctx, source, pod); err != nil {
+			return err
+		}
+		if err := r.patchPodRoleLabels(ctx, source, pod, "", ""); err != nil {
+			return err
+		}
+		logger.Debug("Cleared published pod role", sdklog.String("pod", pod.Name))
+	}
+	return nil
+}
+
+// patchPodRoleLabels sets the zen-lead.io/role and zen-lead.io/role-source labels ("" removes a label);
+// no-op when they already match
+func (r *ServiceDirectorReconciler) patchPodRoleLabels(ctx context.Context, source string, pod *corev1.Pod, role, roleSource string) error {
+	if pod.Labels[LabelRole] == role && pod.Labels[LabelRoleSource] == roleSource {
+		return nil
+	}
+	original := pod.DeepCopy()
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	for key, val := range map[string]string{LabelRole: role, LabelRoleSource: roleSource} {
+		if val == "" {
+			delete(pod.Labels, key)
+		} else {
+			pod.Labels[key] = val
+		}
+	}
+	if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
+		return r.Patch(ctx, pod, client.MergeFrom(original))
+	}, r.Metrics, pod.Namespace, source, "patch_pod_role_label"); err != nil {
+		return fmt.Errorf("failed to update role labels of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// publishRoleCondition sets the zen-lead.io/leader pod condition (no-op when already set)
+func (r *ServiceDirectorReconciler) publishRoleCondition(ctx context.Context, svc *corev1.Service, pod *corev1.Pod, role string) error {
+	status, reason := corev1.ConditionFalse, "Follower"
+	if role == RoleLeader {
+		status, reason = corev1.ConditionTrue, "Leader"
+	}
+	idx := leaderConditionIndex(pod)
+	if idx >= 0 && pod.Status.Conditions[idx].Status == status {
+		return nil
+	}
+
+	original := pod.DeepCopy()
+	newCondition := corev1.PodCondition{
+		Type:               PodConditionLeader,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            fmt.Sprintf("%s of Service %s", role, r.getLeaderServiceName(svc)),
+	}
+	if idx >= 0 {
+		pod.Status.Conditions[idx] = newCondition
+	} else {
+		pod.Status.Conditions = append(pod.Status.Conditions, newCondition)
+	}
+	if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
+		// Strategic merge keys conditions by type, leaving kubelet-owned conditions untouched
+		return r.Status().Patch(ctx, pod, client.StrategicMergeFrom(original))
+	}, r.Metrics, svc.Namespace, svc.Name, "patch_pod_role_condition"); err != nil {
+		return fmt.Errorf("failed to set %s condition on pod %s/%s: %w", PodConditionLeader, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// clearRoleCondition removes the zen-lead.io/leader pod condition (no-op when absent)
+func (r *ServiceDirectorReconciler) clearRoleCondition(ctx context.Context, source string, pod *corev1.Pod) error {
+	idx := leaderConditionIndex(pod)
+	if idx < 0 {
+		return nil
+	}
+	original := pod.DeepCopy()
+	pod.Status.Conditions = append(pod.Status.Conditions[:idx:idx], pod.Status.Conditions[idx+1:]...)
+	if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
+		// Strategic merge deletes the condition by type, leaving kubelet-owned conditions untouched
+		return r.Status().Patch(ctx, pod, client.StrategicMergeFrom(original))
+	}, r.Metrics, pod.Namespace, source, "patch_pod_role_condition"); err != nil {
+		return fmt.Errorf("failed to remove %s condition from pod %s/%s: %w", PodConditionLeader, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// leaderConditionIndex returns the index of the zen-lead.io/leader condition (-1 when absent)
+func leaderConditionIndex(pod *corev1.Pod) int {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == PodConditionLeader {
+			return i
+		}
+	}
+	return -1
+}
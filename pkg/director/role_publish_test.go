@@ -0,0 +1,326 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestGetPublishRoleModes(t *testing.T) {
+	tests := []struct {
+		value             string
+		label, condition  bool
+		expectInvalidMode bool
+	}{
+		{value: ""},
+		{value: "label", label: true},
+		{value: "condition", condition: true},
+		{value: "label, condition", label: true, condition: true},
+		{value: "annotation", expectInvalidMode: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationPublishRoleService: tt.value}}}
+			label, condition, err := getPublishRoleModes(svc)
+			if (err != nil) != tt.expectInvalidMode {
+				t.Fatalf("getPublishRoleModes() error = %v, expectInvalidMode %v", err, tt.expectInvalidMode)
+			}
+			if label != tt.label || condition != tt.condition {
+				t.Errorf("getPublishRoleModes() = (%v, %v), expected (%v, %v)", label, condition, tt.label, tt.condition)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_PublishRole(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationPublishRoleService: "label,condition",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	// Record the order of role label writes
+	var labelWrites []string
+	r := &ServiceDirectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(svc, podA, podB).
+			WithStatusSubresource(&corev1.Pod{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if pod, ok := obj.(*corev1.Pod); ok {
+						labelWrites = append(labelWrites, pod.Name+"="+pod.Labels[LabelRole])
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(50),
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	expectRoles := func(expected map[string]string) {
+		t.Helper()
+		for name, role := range expected {
+			pod := &corev1.Pod{}
+			if err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, pod); err != nil {
+				t.Fatalf("failed to get pod: %v", err)
+			}
+			if got := pod.Labels[LabelRole]; got != role {
+				t.Errorf("pod %s label %s = %q, expected %q", name, LabelRole, got, role)
+			}
+			status := corev1.ConditionFalse
+			if role == RoleLeader {
+				status = corev1.ConditionTrue
+			}
+			found := false
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == PodConditionLeader {
+					found = condition.Status == status
+				}
+				if condition.Type == corev1.PodReady && name == "pod-b" && condition.Status != corev1.ConditionTrue {
+					t.Errorf("pod %s lost its Ready condition", name)
+				}
+			}
+			if !found {
+				t.Errorf("pod %s condition %s != %s", name, PodConditionLeader, status)
+			}
+		}
+	}
+
+	// Publishing disabled on the controller - pods untouched
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(labelWrites) != 0 {
+		t.Fatalf("pods patched with role publishing disabled: %v", labelWrites)
+	}
+
+	r.SetRolePublishing(true)
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	expectRoles(map[string]string{"pod-a": RoleLeader, "pod-b": RoleFollower})
+
+	// Leader fails - the stale leader label is removed before the new leader is labeled
+	labelWrites = nil
+	current := &corev1.Pod{}
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(podA), current); err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	for i := range current.Status.Conditions {
+		if current.Status.Conditions[i].Type == corev1.PodReady {
+			current.Status.Conditions[i].Status = corev1.ConditionFalse
+		}
+	}
+	if err := r.Status().Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update pod status: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints = %v, expected [pod-b]", got)
+	}
+	expectRoles(map[string]string{"pod-a": RoleFollower, "pod-b": RoleLeader})
+	if strings.Join(labelWrites, ",") != "pod-a=follower,pod-b=leader" {
+		t.Errorf("role label writes = %v, expected pod-a demoted before pod-b is promoted", labelWrites)
+	}
+
+	// Steady state - no further writes
+	labelWrites = nil
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(labelWrites) != 0 {
+		t.Errorf("unexpected role label writes in steady state: %v", labelWrites)
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_PublishRoleCleanup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:     "true",
+				AnnotationPublishRoleService: "label,condition",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 3*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", 2*time.Hour, 8080)
+	podC := newActivePod("pod-c", "10.0.0.3", time.Hour, 8080)
+	// Selected, but its role is published by another Service
+	podD := newActivePod("pod-d", "10.0.0.4", 30*time.Minute, 8080)
+	podD.Labels[LabelRole] = RoleLeader
+	podD.Labels[LabelRoleSource] = "other-service"
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(svc, podA, podB, podC, podD).
+			WithStatusSubresource(&corev1.Pod{}).
+			Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	r.SetRolePublishing(true)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+	getPod := func(name string) *corev1.Pod {
+		t.Helper()
+		pod := &corev1.Pod{}
+		if err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, pod); err != nil {
+			t.Fatalf("failed to get pod: %v", err)
+		}
+		return pod
+	}
+	// expectRole checks the role label and condition of a pod ("" expects none)
+	expectRole := func(name, label, condition string) {
+		t.Helper()
+		pod := getPod(name)
+		if got := pod.Labels[LabelRole]; got != label {
+			t.Errorf("pod %s label %s = %q, expected %q", name, LabelRole, got, label)
+		}
+		source := ""
+		if label != "" || condition != "" {
+			source = svc.Name
+		}
+		if got := pod.Labels[LabelRoleSource]; got != source {
+			t.Errorf("pod %s label %s = %q, expected %q", name, LabelRoleSource, got, source)
+		}
+		got := ""
+		if idx := leaderConditionIndex(pod); idx >= 0 {
+			got = RoleFollower
+			if pod.Status.Conditions[idx].Status == corev1.ConditionTrue {
+				got = RoleLeader
+			}
+		}
+		if got != condition {
+			t.Errorf("pod %s condition %s = %q, expected %q", name, PodConditionLeader, got, condition)
+		}
+	}
+	updateService := func(mutate func(*corev1.Service)) {
+		t.Helper()
+		current := &corev1.Service{}
+		if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+			t.Fatalf("failed to get service: %v", err)
+		}
+		mutate(current)
+		if err := r.Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to update service: %v", err)
+		}
+	}
+
+	reconcile()
+	expectRole("pod-a", RoleLeader, RoleLeader)
+	expectRole("pod-b", RoleFollower, RoleFollower)
+	expectRole("pod-c", RoleFollower, RoleFollower)
+	if pod := getPod("pod-d"); pod.Labels[LabelRole] != RoleLeader || pod.Labels[LabelRoleSource] != "other-service" || leaderConditionIndex(pod) >= 0 {
+		t.Errorf("pod-d published by other-service was modified: labels %v", pod.Labels)
+	}
+	conflict := false
+	for len(eventRecorder.Events) > 0 {
+		if event := <-eventRecorder.Events; strings.Contains(event, "PodRoleConflict") && strings.Contains(event, "pod-d (other-service)") {
+			conflict = true
+		}
+	}
+	if !conflict {
+		t.Error("expected PodRoleConflict event for pod-d")
+	}
+
+	// pod-c leaves the selector - its role is cleared
+	current := getPod("pod-c")
+	current.Labels["app"] = "other-app"
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update pod: %v", err)
+	}
+	reconcile()
+	expectRole("pod-c", "", "")
+	expectRole("pod-b", RoleFollower, RoleFollower)
+
+	// Condition mode dropped - only the condition is cleared
+	updateService(func(s *corev1.Service) { s.Annotations[AnnotationPublishRoleService] = "label" })
+	reconcile()
+	expectRole("pod-a", RoleLeader, "")
+	expectRole("pod-b", RoleFollower, "")
+
+	// Publishing removed - every role is cleared
+	updateService(func(s *corev1.Service) { delete(s.Annotations, AnnotationPublishRoleService) })
+	reconcile()
+	expectRole("pod-a", "", "")
+	expectRole("pod-b", "", "")
+
+	// Published again, then the Service opts out - every role is cleared
+	updateService(func(s *corev1.Service) { s.Annotations[AnnotationPublishRoleService] = "condition" })
+	reconcile()
+	expectRole("pod-a", "", RoleLeader)
+	expectRole("pod-b", "", RoleFollower)
+	updateService(func(s *corev1.Service) { delete(s.Annotations, AnnotationEnabledService) })
+	reconcile()
+	expectRole("pod-a", "", "")
+	expectRole("pod-b", "", "")
+	if pod := getPod("pod-d"); pod.Labels[LabelRoleSource] != "other-service" {
+		t.Errorf("pod-d published by other-service was cleared: labels %v", pod.Labels)
+	}
+}
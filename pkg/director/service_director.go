@@ -21,10 +21,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,16 +76,21 @@ const (
 	AnnotationEnabledService = "zen-lead.io/enabled"
 	// AnnotationLeaderServiceNameService allows specifying custom leader service name
 	AnnotationLeaderServiceNameService = "zen-lead.io/leader-service-name"
-	// AnnotationStrategyService specifies leader selection strategy
+	// AnnotationStrategyService specifies leader selection strategy (see LeaderSelector)
 	AnnotationStrategyService = "zen-lead.io/strategy"
 	// AnnotationStickyService enables sticky leader (keep current leader if Ready)
 	AnnotationStickyService = "zen-lead.io/sticky"
-	// AnnotationFailoverMinDelayService specifies minimum delay before failover
+	// AnnotationFailoverMinDelayService specifies a grace period before failing over from a NotReady leader
 	AnnotationFailoverMinDelayService = "zen-lead.io/failover-min-delay"
-	// AnnotationPortsModeService specifies how to handle ports
+	// AnnotationPortsModeService specifies how to shape leader Service ports (mirror, subset, remap)
 	AnnotationPortsModeService = "zen-lead.io/ports-mode"
 	// AnnotationMinReadyDurationService specifies minimum duration pod must be Ready before becoming leader
 	AnnotationMinReadyDurationService = "zen-lead.io/min-ready-duration"
+	// AnnotationPriorityPreemptionService allows a higher-priority pod to take over from a sticky leader
+	AnnotationPriorityPreemptionService = "zen-lead.io/priority-preemption"
+
+	// AnnotationLeaderPriorityPod sets a pod's leader priority (annotation or label, higher wins)
+	AnnotationLeaderPriorityPod = "zen-lead.io/leader-priority"
 
 	// ServiceSuffixService is the suffix for the leader service name
 	ServiceSuffixService = "-leader"
@@ -107,7 +117,7 @@ const (
 
 // ServiceDirectorReconciler reconciles Services with zen-lead.io/enabled annotation
 // to route traffic to leader pods via selector-less Service + EndpointSlice.
-// This is the day-0 non-invasive approach: no CRD required, no pod mutation.
+// This is the day-0 non-invasive approach: no CRD required, no pod mutation (unless role publishing is enabled).
 type ServiceDirectorReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
@@ -144,6 +154,41 @@ type ServiceDirectorReconciler struct {
 
 	// leaderPodCacheTTL is the TTL for leader pod cache entries (stored for cleanup goroutine)
 	leaderPodCacheTTL time.Duration
+
+	// annotationWarnings remembers the last Warning event per service and annotation so a bad
+	// annotation is reported once, not on every reconcile
+	annotationWarnings   map[string]map[string]string
+	annotationWarningsMu sync.Mutex
+
+	// nodeAwareness allows reading and watching Nodes (topology preference, unsafe nodes, endpoint zones)
+	nodeAwareness bool
+
+	// roleProbeConcurrency limits parallel role probes per Service (0 = default)
+	roleProbeConcurrency int
+
+	// roleProbeHTTPClient is used for HTTP role probes (nil = default client)
+	roleProbeHTTPClient *http.Client
+
+	// roleProbeRounds holds the last role probe round per service (namespace/name)
+	roleProbeRounds   map[string]*roleProbeRound
+	roleProbeRoundsMu sync.Mutex
+
+	// roleProbeSecrets allows zen-lead.io/role-probe-secret to read labelled Secrets (off = no Secret access)
+	roleProbeSecrets bool
+
+	// followLease allows zen-lead.io/follow-lease (off = Leases are not cached or watched)
+	followLease bool
+
+	// leaderChanges records recent leader change times per Service (namespace/name) for the failover rate limit
+	leaderChanges   map[string][]time.Time
+	leaderChangesMu sync.Mutex
+
+	// quorumLost tracks Services (namespace/name) below zen-lead.io/min-ready-pods, for loss/restore events
+	quorumLost   map[string]bool
+	quorumLostMu sync.Mutex
+
+	// rolePublishing allows zen-lead.io/publish-role to label pods or set pod conditions (off = no pod mutation)
+	rolePublishing bool
 }
 
 // cachedLeaderPod holds a cached leader pod with metadata
@@ -155,9 +200,10 @@ type cachedLeaderPod struct {
 
 // cachedService holds a Service's selector for efficient matching
 type cachedService struct {
-	name       string
-	selector   labels.Selector
-	lastAccess time.Time // For LRU eviction
+	name        string
+	selector    labels.Selector
+	leaderLabel labels.Selector // zen-lead.io/leader-label (nil = not set or invalid)
+	lastAccess  time.Time       // For LRU eviction
 }
 
 // NewServiceDirectorReconciler creates a new ServiceDirectorReconciler
@@ -357,6 +403,14 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		logger.Info("No pods found for service", sdklog.Operation("reconcile"))
 		r.Recorder.Event(svc, corev1.EventTypeWarning, "NoPodsFound",
 			fmt.Sprintf("No pods found matching Service selector. Leader Service %s will have no endpoints until pods are created.", r.getLeaderServiceName(svc)))
+		if err := r.reconcileFollowersService(ctx, svc, nil, logger); err != nil {
+			duration := time.Since(startTime).Seconds()
+			if r.Metrics != nil {
+				r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "error", duration)
+				r.Metrics.RecordReconciliationError(svc.Namespace, svc.Name, "reconcile_followers_failed")
+			}
+			return ctrl.Result{}, err
+		}
 		if err := r.reconcileLeaderService(ctx, svc, nil, logger); err != nil {
 			duration := time.Since(startTime).Seconds()
 			if r.Metrics != nil {
@@ -374,37 +428,65 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	// Get current leader from EndpointSlice (for failover detection)
 	currentLeaderPod := r.getCurrentLeaderPod(ctx, svc, logger)
+	previousLeaderPod := currentLeaderPod
 
 	// Leader-fast-path - immediately failover if current leader is unhealthy
 	bypassStickiness := false
+	holdLeader := false
+	var requeueAfter time.Duration
 	var failoverStartTime time.Time
 	var failoverReason string
+	eligibility := getServiceEligibility(svc)
 	if currentLeaderPod != nil {
-		// Check if current leader is terminating, not Ready, or has no PodIP
+		// Check if current leader is terminating, not eligible (zen-lead.io/eligibility), or has no PodIP
 		if currentLeaderPod.DeletionTimestamp != nil ||
-			!isPodReady(currentLeaderPod) ||
+			!eligibility.isEligible(currentLeaderPod) ||
 			currentLeaderPod.Status.PodIP == "" {
-			failoverStartTime = time.Now() // Track failover start time
-			logger.Info("Current leader unhealthy, triggering immediate failover",
-				sdklog.Operation("failover"),
-				sdklog.String("leader", currentLeaderPod.Name),
-				sdklog.Bool("terminating", currentLeaderPod.DeletionTimestamp != nil),
-				sdklog.Bool("ready", isPodReady(currentLeaderPod)),
-				sdklog.Bool("hasIP", currentLeaderPod.Status.PodIP != ""))
-			// Determine failover reason
-			if currentLeaderPod.DeletionTimestamp != nil {
-				failoverReason = "terminating"
-			} else if !isPodReady(currentLeaderPod) {
-				failoverReason = "notReady"
-			} else if currentLeaderPod.Status.PodIP == "" {
-				failoverReason = "noIP"
+			failoverMinDelay := r.getFailoverMinDelay(svc)
+			if remaining := r.getFailoverGraceRemaining(svc, currentLeaderPod); remaining > 0 {
+				// Failover grace period - a NotReady (not terminating) leader keeps its EndpointSlice
+				// entry until zen-lead.io/failover-min-delay has elapsed; requeue to decide then
+				holdLeader = true
+				requeueAfter = remaining
+				logger.Info("Current leader NotReady, holding leadership during failover grace period",
+					sdklog.Operation("failover"),
+					sdklog.String("leader", currentLeaderPod.Name),
+					sdklog.Duration("failoverMinDelay", failoverMinDelay),
+					sdklog.Duration("remaining", remaining))
+				r.Recorder.Event(svc, corev1.EventTypeNormal, "FailoverDelayed",
+					fmt.Sprintf("Leader %s is NotReady; keeping it for up to %s (zen-lead.io/failover-min-delay, %s remaining) before failing over",
+						currentLeaderPod.Name, failoverMinDelay, remaining.Round(time.Second)))
+			} else {
+				failoverStartTime = time.Now() // Track failover start time
+				if failoverMinDelay > 0 && currentLeaderPod.DeletionTimestamp == nil {
+					// Grace period elapsed - measure failover latency from when the leader became NotReady
+					if notReadySince := eligibility.notEligibleSince(currentLeaderPod); notReadySince != nil {
+						failoverStartTime = *notReadySince
+					}
+					r.Recorder.Event(svc, corev1.EventTypeWarning, "FailoverGraceExpired",
+						fmt.Sprintf("Leader %s did not recover within %s (zen-lead.io/failover-min-delay); failing over", currentLeaderPod.Name, failoverMinDelay))
+				}
+				logger.Info("Current leader unhealthy, triggering immediate failover",
+					sdklog.Operation("failover"),
+					sdklog.String("leader", currentLeaderPod.Name),
+					sdklog.Bool("terminating", currentLeaderPod.DeletionTimestamp != nil),
+					sdklog.Bool("ready", eligibility.isEligible(currentLeaderPod)),
+					sdklog.Bool("hasIP", currentLeaderPod.Status.PodIP != ""))
+				// Determine failover reason
+				if currentLeaderPod.DeletionTimestamp != nil {
+					failoverReason = "terminating"
+				} else if !eligibility.isEligible(currentLeaderPod) {
+					failoverReason = "notReady"
+				} else if currentLeaderPod.Status.PodIP == "" {
+					failoverReason = "noIP"
+				}
+				// Force new leader selection (bypass stickiness)
+				bypassStickiness = true
+				// Clear cache for this service since leader is unhealthy
+				serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+				r.clearLeaderPodCache(serviceKey)
+				currentLeaderPod = nil
 			}
-			// Force new leader selection (bypass stickiness)
-			bypassStickiness = true
-			// Clear cache for this service since leader is unhealthy
-			serviceKey := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
-			r.clearLeaderPodCache(serviceKey)
-			currentLeaderPod = nil
 		}
 	}
 
@@ -415,7 +497,97 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Select leader pod (with stickiness, unless current leader is unhealthy)
-	leaderPod := r.selectLeaderPod(ctx, svc, podList.Items, bypassStickiness, logger)
+	// During the failover grace period the NotReady leader is kept as-is
+	candidates := r.rankLeaderCandidates(ctx, svc, podList.Items, logger)
+	var leaderPod *corev1.Pod
+	roleProbe, roleProbeConfigured := r.getRoleProbe(svc)
+	var pinnedLeader *corev1.Pod
+	if !holdLeader {
+		var pinRemaining time.Duration
+		if pinnedLeader, pinRemaining = r.getPinnedLeader(svc, podList.Items, candidates.ranked, logger); pinRemaining > 0 {
+			// Requeue when the pin expires so normal selection resumes on time
+			requeueAfter = pinRemaining
+		}
+	}
+	var switchover *switchoverDecision
+	if !holdLeader && pinnedLeader == nil {
+		// Planned switchover (zen-lead.io/switchover-request) - drain the old leader, then hand over
+		var err error
+		if switchover, err = r.reconcileSwitchover(ctx, svc, currentLeaderPod, candidates.ranked, logger); err != nil {
+			logger.Error(err, "Failed to reconcile switchover",
+				sdklog.Operation("switchover"),
+				sdklog.ErrorCode("RECONCILE_SWITCHOVER_FAILED"),
+				sdklog.String("namespace", svc.Namespace),
+				sdklog.String("service", svc.Name))
+			duration := time.Since(startTime).Seconds()
+			if r.Metrics != nil {
+				r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "error", duration)
+				r.Metrics.RecordReconciliationError(svc.Namespace, svc.Name, "reconcile_switchover_failed")
+			}
+			return ctrl.Result{}, err
+		}
+		if switchover != nil && switchover.handedOver != nil {
+			candidates.handedOver = switchover.handedOver.UID
+		}
+		if switchover != nil && switchover.draining != nil {
+			// Old leader keeps serving existing connections but receives no new ones until the drain ends
+			if err := r.drainLeaderEndpoint(ctx, svc, switchover.draining, logger); err != nil {
+				duration := time.Since(startTime).Seconds()
+				if r.Metrics != nil {
+					r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "error", duration)
+					r.Metrics.RecordReconciliationError(svc.Namespace, svc.Name, "reconcile_switchover_failed")
+				}
+				return ctrl.Result{}, err
+			}
+			duration := time.Since(startTime).Seconds()
+			if r.Metrics != nil {
+				r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "success", duration)
+			}
+			return ctrl.Result{RequeueAfter: switchover.requeueAfter}, nil
+		}
+	}
+	if holdLeader {
+		leaderPod = currentLeaderPod
+	} else if pinnedLeader != nil {
+		// Manual pin (zen-lead.io/pinned-leader) overrides stickiness, strategy and leader sources
+		leaderPod = pinnedLeader
+	} else if switchover != nil && switchover.leader != nil {
+		// Planned switchover finished draining - publish the target
+		leaderPod = switchover.leader
+	} else if roleProbeConfigured {
+		// The application decides the primary (zen-lead.io/role-probe); re-probe periodically
+		// because role changes inside the application produce no Kubernetes events
+		leaderPod, requeueAfter = r.selectLeaderByRoleProbe(ctx, svc, roleProbe, currentLeaderPod, candidates.ranked, logger)
+	} else if getFollowLease(svc) != "" {
+		// The pod holding the followed Lease leads (zen-lead.io/follow-lease); requeue at the Lease
+		// expiry because expiry produces no Kubernetes event
+		var leaseRemaining time.Duration
+		leaderPod, leaseRemaining = r.selectLeaderByLease(ctx, svc, podList.Items, candidates.ranked, logger)
+		if leaseRemaining > 0 {
+			requeueAfter = leaseRemaining
+		}
+	} else {
+		leaderPod = r.selectLeaderFromCandidates(ctx, svc, podList.Items, candidates, bypassStickiness, logger)
+	}
+
+	// Flap circuit breaker - rate-limit leader changes (zen-lead.io/failover-max-changes)
+	exempt := holdLeader || pinnedLeader != nil || (switchover != nil && switchover.leader != nil)
+	leaderPod, failoverSuppressed, resetIn := r.applyFailoverCircuitBreaker(svc, previousLeaderPod, leaderPod, podList.Items, exempt, logger)
+	if failoverSuppressed {
+		if leaderPod != nil {
+			// Holding the last-known leader is not a leader change
+			currentLeaderPod = leaderPod
+		}
+		if requeueAfter == 0 || resetIn < requeueAfter {
+			requeueAfter = resetIn
+		}
+	}
+
+	// Quorum guard - no new leader without zen-lead.io/min-ready-pods Ready pods (applies to every leader source)
+	leaderPod, quorumLost := r.applyQuorumGuard(svc, currentLeaderPod, leaderPod, readyPods, logger)
+
+	// N-active mode - additional pods fill the slots behind the leader (zen-lead.io/leader-count)
+	activePods := r.selectActivePods(ctx, svc, candidates.ranked, leaderPod, logger)
 
 	// Detect failover (leader changed) - track leader switch time
 	leaderChanged := false
@@ -457,10 +629,37 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			if currentLeaderPod != nil {
 				if currentLeaderPod.DeletionTimestamp != nil {
 					reason = "terminating"
-				} else if !isPodReady(currentLeaderPod) {
+				} else if !eligibility.isEligible(currentLeaderPod) {
 					reason = "notReady"
 				} else if currentLeaderPod.Status.PodIP == "" {
 					reason = "noIP"
+				} else if failoverSuppressed {
+					// Circuit breaker emptied the leader Service (zen-lead.io/failover-suppress-mode: empty)
+					reason = "circuitOpen"
+				} else if quorumLost {
+					// Leader withdrawn below zen-lead.io/min-ready-pods
+					reason = "quorumLost"
+				} else if pinnedLeader != nil {
+					// Operator pinned another pod
+					reason = "pinned"
+				} else if switchover != nil && switchover.leader != nil {
+					// Planned switchover
+					reason = "switchover"
+				} else if roleProbeConfigured {
+					// Application reported a different primary
+					reason = "roleChanged"
+				} else if getFollowLease(svc) != "" {
+					// Lease acquired by another pod
+					reason = "leaseChanged"
+				} else if hasLeaderLabel(svc) {
+					// External tool moved the leader label
+					reason = "labelChanged"
+				} else if _, unsafe := candidates.unsafePods[currentLeaderPod.UID]; unsafe {
+					// Healthy leader handed over from a cordoned, tainted or spot node
+					reason = "nodeUnsafe"
+				} else {
+					// Healthy leader replaced (e.g. priority preemption)
+					reason = "preempted"
 				}
 			} else if failoverReason != "" {
 				// Use the reason determined when leader is unhealthy
@@ -477,8 +676,23 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	// Reconcile followers first so a new leader leaves the followers before it receives leader traffic
+	if err := r.reconcileFollowersService(ctx, svc, selectFollowerPods(candidates.ranked, activePods), logger); err != nil {
+		logger.Error(err, "Failed to reconcile followers service",
+			sdklog.Operation("reconcile_followers"),
+			sdklog.ErrorCode("RECONCILE_FOLLOWERS_FAILED"),
+			sdklog.String("namespace", svc.Namespace),
+			sdklog.String("service", svc.Name))
+		duration := time.Since(startTime).Seconds()
+		if r.Metrics != nil {
+			r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "error", duration)
+			r.Metrics.RecordReconciliationError(svc.Namespace, svc.Name, "reconcile_followers_failed")
+		}
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile leader Service and EndpointSlice
-	if err := r.reconcileLeaderService(ctx, svc, leaderPod, logger); err != nil {
+	if err := r.reconcileLeaderService(ctx, svc, activePods, logger); err != nil {
 		logger.Error(err, "Failed to reconcile leader service",
 			sdklog.Operation("reconcile_service"),
 			sdklog.ErrorCode("RECONCILE_SERVICE_FAILED"),
@@ -493,6 +707,21 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	// Publish roles onto pods (zen-lead.io/publish-role) once the EndpointSlice reflects the new leader
+	if err := r.publishPodRoles(ctx, svc, podList.Items, activePods, logger); err != nil {
+		logger.Error(err, "Failed to publish pod roles",
+			sdklog.Operation("publish_roles"),
+			sdklog.ErrorCode("PUBLISH_ROLES_FAILED"),
+			sdklog.String("namespace", svc.Namespace),
+			sdklog.String("service", svc.Name))
+		duration := time.Since(startTime).Seconds()
+		if r.Metrics != nil {
+			r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "error", duration)
+			r.Metrics.RecordReconciliationError(svc.Namespace, svc.Name, "publish_roles_failed")
+		}
+		return ctrl.Result{}, err
+	}
+
 	// Record leader duration and pod age (if leader exists)
 	if leaderPod != nil {
 		// Calculate duration since pod creation (or since it became leader)
@@ -512,7 +741,7 @@ func (r *ServiceDirectorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if r.Metrics != nil {
 		r.Metrics.RecordReconciliationDuration(svc.Namespace, svc.Name, "success", duration)
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // getCurrentLeaderPod gets the current leader pod from cache or EndpointSlice (if cache miss)
@@ -640,48 +869,125 @@ func (r *ServiceDirectorReconciler) cleanupLeaderPodCache(ctx context.Context, t
 	}
 }
 
-// selectLeaderPod selects the leader pod using controller-driven selection with stickiness
-// bypassStickiness: if true, forces new leader selection even if current leader exists (leader-fast-path)
-func (r *ServiceDirectorReconciler) selectLeaderPod(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, bypassStickiness bool, logger *sdklog.Logger) *corev1.Pod {
-	// Check if sticky is enabled (default: true)
-	sticky := true
+// leaderCandidates is the ranked set of eligible leader candidates for a Service
+type leaderCandidates struct {
+	// ranked holds the eligible pods, most preferred first
+	ranked           []corev1.Pod
+	selector         LeaderSelector
+	unsafeNodePolicy string
+	unsafePods       map[types.UID]string
+
+	// handedOver is the target of a completed switchover that still leads - kept even without
+	// stickiness and against priority preemption
+	handedOver types.UID
+}
+
+// rankLeaderCandidates filters pods to eligible leader candidates and orders them
+func (r *ServiceDirectorReconciler) rankLeaderCandidates(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, logger *sdklog.Logger) *leaderCandidates {
+	// Filter to eligible candidates (Ready + flap damping)
+	readyPods := r.filterLeaderCandidates(svc, pods, logger)
+
+	// Unsafe nodes (cordoned, NoExecute-tainted, spot) - excluded or ranked last (opt-in)
+	unsafeNodePolicy := r.getUnsafeNodePolicy(svc)
+	var unsafePods map[types.UID]string
+	if unsafeNodePolicy != UnsafeNodePolicyIgnore {
+		unsafePods = r.getUnsafePods(ctx, svc, pods, logger)
+	}
+	if unsafeNodePolicy == UnsafeNodePolicyExclude {
+		candidates := len(readyPods)
+		readyPods = excludeUnsafePods(readyPods, unsafePods)
+		if candidates > 0 && len(readyPods) == 0 {
+			r.Recorder.Event(svc, corev1.EventTypeWarning, "NoSafeLeaderCandidates",
+				fmt.Sprintf("All %d ready pods are on cordoned, NoExecute-tainted or spot nodes (%s=%s)", candidates, AnnotationUnsafeNodePolicyService, UnsafeNodePolicyExclude))
+		}
+	}
+
+	// Order candidates using the configured selection strategy (zen-lead.io/strategy),
+	// then by topology preference, then by leader priority (highest first), with pods on
+	// unsafe nodes last (last-resort policy). Stable sorts keep the previous order for ties.
+	selector := r.getLeaderSelector(svc)
+	if len(readyPods) > 0 {
+		selector.Sort(svc, readyPods)
+		r.sortByTopologyPreference(ctx, svc, readyPods, logger)
+		sortByLeaderPriority(readyPods)
+		if unsafeNodePolicy == UnsafeNodePolicyLastResort {
+			sortUnsafeLast(readyPods, unsafePods)
+		}
+	}
+
+	return &leaderCandidates{
+		ranked:           readyPods,
+		selector:         selector,
+		unsafeNodePolicy: unsafeNodePolicy,
+		unsafePods:       unsafePods,
+	}
+}
+
+// isStickyEnabled reports whether zen-lead.io/sticky is enabled (default: true)
+func isStickyEnabled(svc *corev1.Service) bool {
 	if svc.Annotations != nil {
 		if val, ok := svc.Annotations[AnnotationStickyService]; ok && val == "false" {
-			sticky = false
+			return false
 		}
 	}
+	return true
+}
 
-	// If bypassStickiness is true, skip sticky check (force new leader selection)
-	// If sticky, check existing EndpointSlice for current leader
-	if sticky && !bypassStickiness {
-		leaderServiceName := r.getLeaderServiceName(svc)
-		endpointSlice := &discoveryv1.EndpointSlice{}
-		endpointSliceKey := types.NamespacedName{
-			Name:      leaderServiceName,
-			Namespace: svc.Namespace,
-		}
+// selectLeaderPod selects the leader pod using controller-driven selection with stickiness
+// bypassStickiness: if true, forces new leader selection even if current leader exists (leader-fast-path)
+func (r *ServiceDirectorReconciler) selectLeaderPod(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, bypassStickiness bool, logger *sdklog.Logger) *corev1.Pod {
+	candidates := r.rankLeaderCandidates(ctx, svc, pods, logger)
+	// A manual pin (zen-lead.io/pinned-leader) overrides stickiness and strategy
+	if pinned, _ := r.getPinnedLeader(svc, pods, candidates.ranked, logger); pinned != nil {
+		return pinned
+	}
+	return r.selectLeaderFromCandidates(ctx, svc, pods, candidates, bypassStickiness, logger)
+}
 
-		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
-			return r.Get(ctx, endpointSliceKey, endpointSlice)
-		}, r.Metrics, svc.Namespace, svc.Name, "get_endpointslice_sticky"); err == nil {
-			// Found existing EndpointSlice - check if current leader is still Ready (match by UID)
-			for _, endpoint := range endpointSlice.Endpoints {
-				if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" && endpoint.TargetRef.UID != "" {
-					// Find the pod by UID (restart-safe)
-					for i := range pods {
-						pod := &pods[i]
-						if string(pod.UID) == string(endpoint.TargetRef.UID) {
-							if isPodReady(pod) {
-								logger.Debug("Keeping sticky leader", sdklog.String("pod", pod.Name), sdklog.String("uid", string(pod.UID)))
-								if r.Metrics != nil {
-									r.Metrics.RecordStickyLeaderHit(svc.Namespace, svc.Name)
-								}
-								return pod
-							}
-							break
-						}
-					}
+// selectLeaderFromCandidates selects the leader pod from ranked candidates, keeping the sticky leader when possible
+func (r *ServiceDirectorReconciler) selectLeaderFromCandidates(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, candidates *leaderCandidates, bypassStickiness bool, logger *sdklog.Logger) *corev1.Pod {
+	// An external tool decides the leader by labelling it (zen-lead.io/leader-label) - stickiness does not apply
+	if hasLeaderLabel(svc) {
+		return r.selectLeaderByLabel(svc, candidates.ranked, logger)
+	}
+
+	readyPods := candidates.ranked
+	unsafePods := candidates.unsafePods
+
+	// If bypassStickiness is true, skip sticky check (force new leader selection)
+	// If sticky, or the current leader is a completed switchover's target, check existing EndpointSlice for current leader
+	if (isStickyEnabled(svc) || candidates.handedOver != "") && !bypassStickiness {
+		if pod := r.getStickyLeader(ctx, svc, pods, logger); pod != nil {
+			// Priority preemption - a higher-priority eligible pod takes over (opt-in, not against a switchover)
+			// Proactive handover - the leader's node is cordoned, tainted NoExecute or spot
+			var preemptor *corev1.Pod
+			if pod.UID != candidates.handedOver {
+				preemptor = r.findPriorityPreemptor(svc, pod, readyPods)
+			}
+			if preemptor != nil {
+				logger.Info("Higher-priority pod preempting sticky leader",
+					sdklog.Operation("select_leader"),
+					sdklog.String("leader", pod.Name),
+					sdklog.String("preemptor", preemptor.Name),
+					sdklog.Int64("leaderPriority", getPodLeaderPriority(pod)),
+					sdklog.Int64("preemptorPriority", getPodLeaderPriority(preemptor)))
+				r.Recorder.Event(svc, corev1.EventTypeNormal, "LeaderPreempted",
+					fmt.Sprintf("Pod %s (priority %d) preempts leader %s (priority %d)", preemptor.Name, getPodLeaderPriority(preemptor), pod.Name, getPodLeaderPriority(pod)))
+			} else if reason, unsafe := unsafePods[pod.UID]; unsafe &&
+				(candidates.unsafeNodePolicy == UnsafeNodePolicyExclude || hasSafeCandidate(readyPods, unsafePods, pod.UID)) {
+				logger.Info("Leader node is unsafe, handing over proactively",
+					sdklog.Operation("select_leader"),
+					sdklog.String("leader", pod.Name),
+					sdklog.String("node", pod.Spec.NodeName),
+					sdklog.String("reason", reason))
+				r.Recorder.Event(svc, corev1.EventTypeWarning, "LeaderNodeUnsafe",
+					fmt.Sprintf("Leader %s is on node %s (%s); handing over proactively", pod.Name, pod.Spec.NodeName, reason))
+			} else {
+				logger.Debug("Keeping sticky leader", sdklog.String("pod", pod.Name), sdklog.String("uid", string(pod.UID)))
+				if r.Metrics != nil {
+					r.Metrics.RecordStickyLeaderHit(svc.Namespace, svc.Name)
 				}
+				return pod
 			}
 		}
 		// Sticky leader is not available
@@ -690,21 +996,73 @@ func (r *ServiceDirectorReconciler) selectLeaderPod(ctx context.Context, svc *co
 		}
 	}
 
-	// Filter to Ready pods only (apply flap damping if configured)
+	if len(readyPods) == 0 {
+		logger.Info("No ready pods found for service", sdklog.Operation("select_leader"))
+		// Emit event for no ready pods scenario
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "NoReadyPods",
+			fmt.Sprintf("No ready pods available for leader selection. Leader Service %s will have no endpoints until at least one pod becomes Ready.", r.getLeaderServiceName(svc)))
+		return nil
+	}
+
+	// Return most preferred Ready pod
+	leaderPod := &readyPods[0]
+	logger.Info("Selected new leader pod", sdklog.Operation("select_leader"), sdklog.String("pod", leaderPod.Name), sdklog.String("strategy", candidates.selector.Name()))
+	return leaderPod
+}
+
+// getStickyLeader returns the current leader from the existing EndpointSlice if it is still Ready.
+// Only the first pod endpoint is the leader; further endpoints are N-active slots.
+func (r *ServiceDirectorReconciler) getStickyLeader(ctx context.Context, svc *corev1.Service, pods []corev1.Pod, logger *sdklog.Logger) *corev1.Pod {
+	endpointSlice := &discoveryv1.EndpointSlice{}
+	endpointSliceKey := types.NamespacedName{
+		Name:      r.getLeaderServiceName(svc),
+		Namespace: svc.Namespace,
+	}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Get(ctx, endpointSliceKey, endpointSlice)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_endpointslice_sticky"); err != nil {
+		return nil
+	}
+
+	// Found existing EndpointSlice - check if current leader is still Ready (match by UID)
+	for _, endpoint := range endpointSlice.Endpoints {
+		if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" || endpoint.TargetRef.UID == "" {
+			continue
+		}
+		// Find the pod by UID (restart-safe)
+		for i := range pods {
+			pod := &pods[i]
+			if pod.UID == endpoint.TargetRef.UID {
+				if getServiceEligibility(svc).isEligible(pod) {
+					return pod
+				}
+				logger.Debug("Sticky leader not eligible", sdklog.String("pod", pod.Name))
+				return nil
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// filterLeaderCandidates returns the pods eligible for leadership (zen-lead.io/eligibility, Ready by
+// default, and eligible for at least zen-lead.io/min-ready-duration when flap damping is configured)
+func (r *ServiceDirectorReconciler) filterLeaderCandidates(svc *corev1.Service, pods []corev1.Pod, logger *sdklog.Logger) []corev1.Pod {
 	// Pre-allocate with estimated capacity (typically most pods are ready)
 	readyPods := make([]corev1.Pod, 0, len(pods))
 	minReadyDuration := r.getMinReadyDuration(svc)
+	eligibility := r.getPodEligibility(svc)
 	now := time.Now()
 
 	for i := range pods { //nolint:gocritic // rangeValCopy: using index to avoid copy
 		pod := &pods[i]
-		if !isPodReady(pod) {
+		if !eligibility.isEligible(pod) {
 			continue
 		}
 
-		// Flap damping - pod must be Ready for at least minReadyDuration
+		// Flap damping - pod must be eligible for at least minReadyDuration
 		if minReadyDuration > 0 {
-			readySince := r.getPodReadySince(pod)
+			readySince := eligibility.eligibleSince(pod)
 			if readySince == nil || now.Sub(*readySince) < minReadyDuration {
 				logger.Debug("Pod not ready long enough",
 					sdklog.String("pod", pod.Name),
@@ -721,36 +1079,58 @@ func (r *ServiceDirectorReconciler) selectLeaderPod(ctx context.Context, svc *co
 
 		readyPods = append(readyPods, *pod)
 	}
+	return readyPods
+}
 
-	if len(readyPods) == 0 {
-		logger.Info("No ready pods found for service", sdklog.Operation("select_leader"))
-		// Emit event for no ready pods scenario
-		r.Recorder.Event(svc, corev1.EventTypeWarning, "NoReadyPods",
-			fmt.Sprintf("No ready pods available for leader selection. Leader Service %s will have no endpoints until at least one pod becomes Ready.", r.getLeaderServiceName(svc)))
+// findPriorityPreemptor returns the highest-priority candidate that outranks the sticky leader,
+// or nil if priority preemption is disabled (zen-lead.io/priority-preemption) or no candidate outranks it
+func (r *ServiceDirectorReconciler) findPriorityPreemptor(svc *corev1.Service, leader *corev1.Pod, candidates []corev1.Pod) *corev1.Pod {
+	if svc.Annotations == nil || svc.Annotations[AnnotationPriorityPreemptionService] != "true" {
 		return nil
 	}
-
-	// Sort by creation timestamp (earliest first), then by name (lexical) as tie-breaker
-	sort.Slice(readyPods, func(i, j int) bool {
-		if !readyPods[i].CreationTimestamp.Equal(&readyPods[j].CreationTimestamp) {
-			return readyPods[i].CreationTimestamp.Before(&readyPods[j].CreationTimestamp)
+	leaderPriority := getPodLeaderPriority(leader)
+	var preemptor *corev1.Pod
+	for i := range candidates {
+		candidate := &candidates[i]
+		if candidate.UID == leader.UID {
+			continue
 		}
-		return readyPods[i].Name < readyPods[j].Name
-	})
+		priority := getPodLeaderPriority(candidate)
+		if priority > leaderPriority && (preemptor == nil || priority > getPodLeaderPriority(preemptor)) {
+			preemptor = candidate
+		}
+	}
+	return preemptor
+}
 
-	// Defensive check: ensure we have at least one pod (should never happen due to earlier check)
-	if len(readyPods) == 0 {
-		return nil
+// getPodLeaderPriority returns the pod's leader priority from the zen-lead.io/leader-priority
+// annotation or label (annotation wins). Missing or invalid values default to 0.
+func getPodLeaderPriority(pod *corev1.Pod) int64 {
+	val, ok := pod.Annotations[AnnotationLeaderPriorityPod]
+	if !ok {
+		val, ok = pod.Labels[AnnotationLeaderPriorityPod]
+	}
+	if !ok || val == "" {
+		return 0
+	}
+	priority, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64)
+	if err != nil {
+		return 0
 	}
+	return priority
+}
 
-	// Return earliest Ready pod
-	leaderPod := &readyPods[0]
-	logger.Info("Selected new leader pod", sdklog.Operation("select_leader"), sdklog.String("pod", leaderPod.Name))
-	return leaderPod
+// sortByLeaderPriority orders pods by leader priority (highest first), preserving the existing
+// order for pods with equal priority
+func sortByLeaderPriority(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return getPodLeaderPriority(&pods[i]) > getPodLeaderPriority(&pods[j])
+	})
 }
 
 // reconcileLeaderService creates or updates the selector-less leader Service and EndpointSlice
-func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context, svc *corev1.Service, leaderPod *corev1.Pod, logger *sdklog.Logger) error {
+// activePods lists the pods to route to, leader first (nil when there is no leader)
+func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context, svc *corev1.Service, activePods []*corev1.Pod, logger *sdklog.Logger) error {
 	// Create tracing span
 	tracer := observability.GetTracer("zen-lead-service-director")
 	ctx, span := tracer.Start(ctx, "reconcile_leader_service",
@@ -761,6 +1141,10 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 	defer span.End()
 
 	leaderServiceName := r.getLeaderServiceName(svc)
+	var leaderPod *corev1.Pod
+	if len(activePods) > 0 {
+		leaderPod = activePods[0]
+	}
 
 	// Create or update selector-less leader Service
 	leaderService := &corev1.Service{}
@@ -813,6 +1197,10 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 		// Use empty ports list for Service (will be empty until ports resolve)
 		leaderPorts = []corev1.ServicePort{}
 		leaderPod = nil // Prevent EndpointSlice creation
+		activePods = nil
+	} else {
+		// N-active pods must share the leader's resolved ports (fail-closed per pod)
+		activePods = r.filterActivePodsByPorts(svc, leaderPorts, activePods, logger)
 	}
 
 	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
@@ -834,7 +1222,15 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 			leaderAnnotations[AnnotationLeaderPodName] = leaderPod.Name
 			leaderAnnotations[AnnotationLeaderPodUID] = string(leaderPod.UID)
 			leaderAnnotations[AnnotationLeaderLastSwitchTime] = time.Now().Format(time.RFC3339)
+			epoch, err := r.nextLeaderEpoch(ctx, svc, leaderServiceName, nil)
+			if err != nil {
+				return err
+			}
+			leaderAnnotations[AnnotationLeaderEpoch] = epoch
+		} else {
+			delete(leaderAnnotations, AnnotationLeaderEpoch)
 		}
+		setActivePodAnnotations(leaderAnnotations, activePods)
 
 		leaderService = &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
@@ -860,6 +1256,7 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 		}
 
 		// Handle headless Services - if source is headless, default leader to ClusterIP
+		// (zen-lead.io/leader-service-mode: headless keeps it headless)
 		if svc.Spec.ClusterIP == corev1.ClusterIPNone {
 			leaderService.Spec.Type = corev1.ServiceTypeClusterIP
 			leaderService.Spec.ClusterIP = "" // Let Kubernetes assign ClusterIP
@@ -869,6 +1266,11 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 		if leaderService.Spec.Type == "" {
 			leaderService.Spec.Type = corev1.ServiceTypeClusterIP
 		}
+		r.applyLeaderServiceMode(svc, leaderService, false)
+		// Mirror IP families, topology routing and traffic policy of the source Service
+		r.mirrorIPFamilies(svc, leaderService)
+		mirrorTopologyRouting(svc, leaderService)
+		r.reconcileTrafficPolicy(svc, leaderService, nil, logger)
 
 		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
 			return r.Create(ctx, leaderService)
@@ -908,6 +1310,10 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 		if leaderService.Spec.Type == "" {
 			leaderService.Spec.Type = corev1.ServiceTypeClusterIP
 		}
+		r.applyLeaderServiceMode(svc, leaderService, true)
+		r.mirrorIPFamilies(svc, leaderService)
+		mirrorTopologyRouting(svc, leaderService)
+		r.reconcileTrafficPolicy(svc, leaderService, originalService, logger)
 
 		// Update leader annotations (add pod-name, pod-uid, last-switch-time)
 		if leaderService.Annotations == nil {
@@ -915,6 +1321,7 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 		}
 		oldLeaderName := leaderService.Annotations[AnnotationLeaderPodName]
 		oldLeaderUID := leaderService.Annotations[AnnotationLeaderPodUID]
+		oldActivePods := leaderService.Annotations[AnnotationActivePods]
 
 		if leaderPod != nil {
 			leaderService.Annotations["zen-lead.io/current-leader"] = leaderPod.Name
@@ -936,22 +1343,44 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 			delete(leaderService.Annotations, AnnotationLeaderPodUID)
 			// Keep last switch time for debugging
 		}
+		// Fencing token - every leader change (by UID, including to or from no leader) bumps the epoch
+		patch := client.MergeFrom(originalService)
+		if newLeaderUID := leaderService.Annotations[AnnotationLeaderPodUID]; newLeaderUID != oldLeaderUID {
+			epoch, err := r.nextLeaderEpoch(ctx, svc, leaderServiceName, originalService)
+			if err != nil {
+				return err
+			}
+			leaderService.Annotations[AnnotationLeaderEpoch] = epoch
+			// Optimistic lock: an epoch computed from a stale cached read must never be written
+			patch = client.MergeFromWithOptions(originalService, client.MergeFromWithOptimisticLock{})
+		}
+		setActivePodAnnotations(leaderService.Annotations, activePods)
+		if newActivePods := leaderService.Annotations[AnnotationActivePods]; oldActivePods != newActivePods &&
+			(len(activePods) > 1 || strings.Contains(oldActivePods, ",")) {
+			r.Recorder.Event(svc, corev1.EventTypeNormal, "ActivePodsChanged",
+				fmt.Sprintf("Active pods for %s changed from [%s] to [%s]", leaderServiceName, oldActivePods, newActivePods))
+		}
 
 		if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
-			return r.Patch(ctx, leaderService, client.MergeFrom(originalService))
+			return r.Patch(ctx, leaderService, patch)
 		}, r.Metrics, svc.Namespace, svc.Name, "patch_leader_service"); err != nil {
 			return fmt.Errorf("failed to patch leader service: %w", err)
 		}
 	}
 
 	// Create or update EndpointSlice
-	if err := r.reconcileEndpointSlice(ctx, svc, leaderServiceName, leaderPod, leaderPorts, logger); err != nil {
+	if err := r.reconcileEndpointSlice(ctx, svc, leaderServiceName, activePods, leaderPorts, leaderEpochAnnotations(leaderService), logger); err != nil {
 		return fmt.Errorf("failed to reconcile endpoint slice: %w", err)
 	}
 
+	// Alias Services route to the same active pods under additional names
+	if err := r.reconcileLeaderServiceAliases(ctx, svc, leaderService, activePods, leaderPorts, logger); err != nil {
+		return fmt.Errorf("failed to reconcile leader service aliases: %w", err)
+	}
+
 	// Record leader stability and endpoint status
 	if r.Metrics != nil {
-		if leaderPod != nil && isPodReady(leaderPod) {
+		if leaderPod != nil && getServiceEligibility(svc).isEligible(leaderPod) {
 			r.Metrics.RecordLeaderStable(svc.Namespace, svc.Name, true)
 			r.Metrics.RecordLeaderServiceWithoutEndpoints(svc.Namespace, svc.Name, false)
 		} else {
@@ -964,11 +1393,20 @@ func (r *ServiceDirectorReconciler) reconcileLeaderService(ctx context.Context,
 }
 
 // resolveServicePorts resolves Service ports to EndpointSlice ports, handling named targetPort
+// and zen-lead.io/ports-mode port shaping.
 // Fail-closed: if any named port cannot be resolved, returns error (no fallback)
 func (r *ServiceDirectorReconciler) resolveServicePorts(svc *corev1.Service, leaderPod *corev1.Pod) ([]corev1.ServicePort, error) {
-	ports := make([]corev1.ServicePort, 0, len(svc.Spec.Ports))
+	sourcePorts, err := selectServicePorts(svc)
+	if err != nil {
+		// Fail-closed: never fall back to mirroring ports the user meant to hide
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidPortsMode",
+			fmt.Sprintf("Invalid ports configuration: %v. EndpointSlice will have no endpoints until fixed.", err))
+		return nil, err
+	}
+
+	ports := make([]corev1.ServicePort, 0, len(sourcePorts))
 
-	for _, svcPort := range svc.Spec.Ports {
+	for _, svcPort := range sourcePorts {
 		resolvedPort := svcPort.DeepCopy()
 
 		// Resolve targetPort
@@ -1021,8 +1459,9 @@ func (r *ServiceDirectorReconciler) resolveNamedPort(pod *corev1.Pod, portName s
 	return 0, fmt.Errorf("named port %s not found in pod %s", portName, pod.Name)
 }
 
-// reconcileEndpointSlice creates or updates EndpointSlice pointing to leader pod
-func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context, svc *corev1.Service, leaderServiceName string, leaderPod *corev1.Pod, servicePorts []corev1.ServicePort, logger *sdklog.Logger) error {
+// reconcileEndpointSlice creates or updates the EndpointSlices pointing to the active pods (leader first), one per
+// IP family of the Service, setting annotations on them. Slices of families the Service no longer has are deleted.
+func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context, svc *corev1.Service, leaderServiceName string, activePods []*corev1.Pod, servicePorts []corev1.ServicePort, annotations map[string]string, logger *sdklog.Logger) error {
 	// Create tracing span
 	tracer := observability.GetTracer("zen-lead-service-director")
 	ctx, span := tracer.Start(ctx, "reconcile_endpointslice",
@@ -1032,11 +1471,9 @@ func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context,
 		))
 	defer span.End()
 
-	endpointSliceName := leaderServiceName
-	endpointSlice := &discoveryv1.EndpointSlice{}
-	endpointSliceKey := types.NamespacedName{
-		Name:      endpointSliceName,
-		Namespace: svc.Namespace,
+	var leaderPod *corev1.Pod
+	if len(activePods) > 0 {
+		leaderPod = activePods[0]
 	}
 
 	// Convert ServicePorts to EndpointPorts (using resolved targetPort)
@@ -1072,69 +1509,63 @@ func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context,
 		}
 	}
 
-	// Build endpoint from pod
-	var endpointAddresses []string
-	var nodeName *string
-	var targetRef *corev1.ObjectReference
-
-	if leaderPod != nil && leaderPod.Status.PodIP != "" {
-		endpointAddresses = []string{leaderPod.Status.PodIP}
-		if leaderPod.Spec.NodeName != "" {
-			nodeName = &leaderPod.Spec.NodeName
-		}
-		targetRef = &corev1.ObjectReference{
-			Kind:      "Pod",
-			Namespace: leaderPod.Namespace,
-			Name:      leaderPod.Name,
-			UID:       leaderPod.UID,
-		}
-	}
-
-	// Determine address type from pod IP
-	addressType := discoveryv1.AddressTypeIPv4
-	if leaderPod != nil && leaderPod.Status.PodIP != "" {
-		// Use net.ParseIP for accurate IPv6 detection
-		ip := net.ParseIP(leaderPod.Status.PodIP)
-		if ip != nil && ip.To4() == nil {
-			addressType = discoveryv1.AddressTypeIPv6
-		}
-	}
-
-	// Determine ready condition from pod readiness
-	var ready *bool
-	if leaderPod != nil {
-		for _, condition := range leaderPod.Status.Conditions {
-			if condition.Type == corev1.PodReady {
-				b := condition.Status == corev1.ConditionTrue
-				ready = &b
-				break
+	// One slice per IP family of the Service (an IPv4 and an IPv6 slice on dual-stack). Pods without an
+	// address of a family are left out of that family's slice.
+	eligibility := getServiceEligibility(svc)
+	topology := r.getEndpointTopology(ctx, svc, activePods, logger)
+	addressTypes := serviceAddressTypes(svc, leaderPod)
+	endpointSliceNames := make(map[string]struct{}, len(addressTypes))
+	for i, addressType := range addressTypes {
+		endpoints := make([]discoveryv1.Endpoint, 0, len(activePods))
+		for _, pod := range activePods {
+			if podIPForAddressType(pod, addressType) == "" {
+				continue
 			}
+			endpoints = append(endpoints, buildPodEndpoint(pod, addressType, eligibility, topology))
 		}
-		if ready == nil {
-			b := false
-			ready = &b
+		if len(endpoints) == 0 {
+			endpoints = append(endpoints, buildPodEndpoint(nil, addressType, eligibility, topology))
+		}
+		endpointSliceName := endpointSliceNameForFamily(leaderServiceName, addressType, i == 0)
+		endpointSliceNames[endpointSliceName] = struct{}{}
+		if err := r.applyEndpointSlice(ctx, svc, leaderServiceName, endpointSliceName, addressType, endpoints, endpointPorts, annotations, leaderPod, logger); err != nil {
+			return err
 		}
-	} else {
-		b := false
-		ready = &b
 	}
+	return r.deleteStaleEndpointSlices(ctx, svc, leaderServiceName, endpointSliceNames, logger)
+}
 
-	endpoint := discoveryv1.Endpoint{
-		Addresses: endpointAddresses,
-		Conditions: discoveryv1.EndpointConditions{
-			Ready: ready,
-		},
-		NodeName:  nodeName,
-		TargetRef: targetRef,
+// applyEndpointSlice creates or updates one EndpointSlice of a managed Service. A slice whose address type
+// changed is replaced, as the address type of an EndpointSlice is immutable.
+func (r *ServiceDirectorReconciler) applyEndpointSlice(ctx context.Context, svc *corev1.Service, leaderServiceName, endpointSliceName string, addressType discoveryv1.AddressType, endpoints []discoveryv1.Endpoint, endpointPorts []discoveryv1.EndpointPort, annotations map[string]string, leaderPod *corev1.Pod, logger *sdklog.Logger) error {
+	endpointSlice := &discoveryv1.EndpointSlice{}
+	endpointSliceKey := types.NamespacedName{
+		Name:      endpointSliceName,
+		Namespace: svc.Namespace,
 	}
 
-	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+	err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
 		return r.Get(ctx, endpointSliceKey, endpointSlice)
-	}, r.Metrics, svc.Namespace, svc.Name, "get_endpointslice_reconcile"); err != nil {
-		if client.IgnoreNotFound(err) != nil {
-			return fmt.Errorf("failed to get endpoint slice %s/%s for service %s/%s: %w",
-				endpointSliceKey.Namespace, endpointSliceKey.Name, svc.Namespace, svc.Name, err)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_endpointslice_reconcile")
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get endpoint slice %s/%s for service %s/%s: %w",
+			endpointSliceKey.Namespace, endpointSliceKey.Name, svc.Namespace, svc.Name, err)
+	}
+	exists := err == nil
+	if exists && endpointSlice.AddressType != addressType {
+		if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
+			return client.IgnoreNotFound(r.Delete(ctx, endpointSlice))
+		}, r.Metrics, svc.Namespace, svc.Name, "delete_endpointslice_address_type"); err != nil {
+			return fmt.Errorf("failed to replace endpoint slice %s/%s with address type %s: %w",
+				endpointSliceKey.Namespace, endpointSliceKey.Name, addressType, err)
 		}
+		logger.Info("Replacing endpoint slice with changed address type",
+			sdklog.String("endpointslice", endpointSliceName),
+			sdklog.String("from", string(endpointSlice.AddressType)),
+			sdklog.String("to", string(addressType)))
+		exists = false
+	}
+	if !exists {
 		// EndpointSlice doesn't exist, create it
 		// Filter GitOps labels to prevent ownership conflicts
 		endpointSliceLabels := filterGitOpsLabels(svc.Labels)
@@ -1145,9 +1576,10 @@ func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context,
 
 		endpointSlice = &discoveryv1.EndpointSlice{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      endpointSliceName,
-				Namespace: svc.Namespace,
-				Labels:    endpointSliceLabels,
+				Name:        endpointSliceName,
+				Namespace:   svc.Namespace,
+				Labels:      endpointSliceLabels,
+				Annotations: annotations,
 				OwnerReferences: []metav1.OwnerReference{
 					{
 						APIVersion: "v1",
@@ -1167,7 +1599,7 @@ func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context,
 				},
 			},
 			AddressType: addressType,
-			Endpoints:   []discoveryv1.Endpoint{endpoint},
+			Endpoints:   endpoints,
 			Ports:       endpointPorts,
 		}
 
@@ -1206,9 +1638,14 @@ func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context,
 
 	// EndpointSlice exists, update it
 	originalEndpointSlice := endpointSlice.DeepCopy()
-	endpointSlice.Endpoints = []discoveryv1.Endpoint{endpoint}
+	endpointSlice.Endpoints = endpoints
 	endpointSlice.Ports = endpointPorts
-	endpointSlice.AddressType = addressType
+	if len(annotations) > 0 && endpointSlice.Annotations == nil {
+		endpointSlice.Annotations = make(map[string]string, len(annotations))
+	}
+	for k, v := range annotations {
+		endpointSlice.Annotations[k] = v
+	}
 
 	// Use fast retry for failover-critical operation
 	if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
@@ -1232,6 +1669,68 @@ func (r *ServiceDirectorReconciler) reconcileEndpointSlice(ctx context.Context,
 	return nil
 }
 
+// podAddressType returns the EndpointSlice address type for a pod's primary IP (IPv4 when unknown)
+func podAddressType(pod *corev1.Pod) discoveryv1.AddressType {
+	if pod != nil && pod.Status.PodIP != "" {
+		// Use net.ParseIP for accurate IPv6 detection
+		ip := net.ParseIP(pod.Status.PodIP)
+		if ip != nil && ip.To4() == nil {
+			return discoveryv1.AddressTypeIPv6
+		}
+	}
+	return discoveryv1.AddressTypeIPv4
+}
+
+// buildPodEndpoint builds an EndpointSlice endpoint for a pod's address of the given family (an empty,
+// not-ready endpoint for nil).
+// The endpoint is ready when the pod meets the Service's eligibility criteria, so pods selected under
+// zen-lead.io/eligibility "running" or "containers-ready:<name>" receive traffic before PodReady.
+// Zone, zone hints and hostname (pods with spec.hostname and spec.subdomain, e.g. StatefulSets) are filled
+// in like the Kubernetes EndpointSlice controller does, for meshes and proxies that rely on them.
+func buildPodEndpoint(pod *corev1.Pod, addressType discoveryv1.AddressType, eligibility podEligibility, topology endpointTopology) discoveryv1.Endpoint {
+	var endpointAddresses []string
+	var nodeName, zone, hostname *string
+	var targetRef *corev1.ObjectReference
+	var hints *discoveryv1.EndpointHints
+
+	if ip := podIPForAddressType(pod, addressType); ip != "" {
+		endpointAddresses = []string{ip}
+		if pod.Spec.NodeName != "" {
+			nodeName = &pod.Spec.NodeName
+			if nodeZone := topology.zones[pod.Spec.NodeName]; nodeZone != "" {
+				zone = &nodeZone
+				if topology.hints {
+					hints = &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: nodeZone}}}
+				}
+			}
+		}
+		if pod.Spec.Hostname != "" && pod.Spec.Subdomain != "" {
+			hostname = &pod.Spec.Hostname
+		}
+		targetRef = &corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       pod.UID,
+		}
+	}
+
+	// Determine ready condition from pod eligibility (PodReady by default)
+	ready := pod != nil && eligibility.isEligible(pod)
+
+	return discoveryv1.Endpoint{
+		Addresses: endpointAddresses,
+		Conditions: discoveryv1.EndpointConditions{
+			Ready: &ready,
+		},
+		Hostname:  hostname,
+		NodeName:  nodeName,
+		Zone:      zone,
+		Hints:     hints,
+		TargetRef: targetRef,
+	}
+}
+
 // updateResourceTotals updates the total count metrics for leader Services and EndpointSlices
 // Uses context timeout to prevent hanging on slow API server
 func (r *ServiceDirectorReconciler) updateResourceTotals(ctx context.Context, namespace string, logger *sdklog.Logger) {
@@ -1278,6 +1777,9 @@ func (r *ServiceDirectorReconciler) updateResourceTotals(ctx context.Context, na
 
 // cleanupLeaderResources removes leader Service and EndpointSlice when annotation is removed
 func (r *ServiceDirectorReconciler) cleanupLeaderResources(ctx context.Context, svcName types.NamespacedName, logger *sdklog.Logger) (ctrl.Result, error) {
+	r.forgetAnnotationWarnings(fmt.Sprintf("%s/%s", svcName.Namespace, svcName.Name))
+	r.forgetRoleProbeRound(fmt.Sprintf("%s/%s", svcName.Namespace, svcName.Name))
+
 	// Try to determine leader service name (best effort)
 	svc := &corev1.Service{}
 	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
@@ -1302,6 +1804,18 @@ func (r *ServiceDirectorReconciler) cleanupLeaderResources(ctx context.Context,
 			}
 			logger.Info("Deleted leader service", sdklog.Operation("delete_service"), sdklog.String("service", leaderServiceName))
 		}
+
+		// Delete followers Service if one was created
+		if err := r.deleteFollowersService(ctx, svcName.Namespace, svcName.Name, logger); err != nil {
+			logger.Error(err, "Failed to delete followers service", sdklog.String("service", getFollowersServiceName(svc)))
+			return ctrl.Result{}, err
+		}
+
+		// Delete leader alias Services
+		if err := r.deleteLeaderServiceAliases(ctx, svcName.Namespace, svcName.Name, nil, logger); err != nil {
+			logger.Error(err, "Failed to delete leader alias services", sdklog.String("service", svcName.Name))
+			return ctrl.Result{}, err
+		}
 	} else {
 		// Service doesn't exist - try to find and delete leader service by label
 		leaderServiceList := &corev1.ServiceList{}
@@ -1330,6 +1844,14 @@ func (r *ServiceDirectorReconciler) cleanupLeaderResources(ctx context.Context,
 		}
 	}
 
+	// Clear pod roles published for the Service (zen-lead.io/publish-role); needs the role-publishing RBAC
+	if r.rolePublishing {
+		if err := r.clearPodRoles(ctx, svcName.Namespace, svcName.Name, nil, logger); err != nil {
+			logger.Error(err, "Failed to clear published pod roles", sdklog.String("service", svcName.Name))
+			return ctrl.Result{}, err
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -1349,12 +1871,7 @@ func isPodReady(pod *corev1.Pod) bool {
 // getPodReadySince returns the time when the pod became Ready (LastTransitionTime of Ready condition)
 // Returns nil if pod is not currently Ready (flap damping)
 func (r *ServiceDirectorReconciler) getPodReadySince(pod *corev1.Pod) *time.Time {
-	for _, condition := range pod.Status.Conditions {
-		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
-			return &condition.LastTransitionTime.Time
-		}
-	}
-	return nil
+	return podEligibility{mode: EligibilityReady}.eligibleSince(pod)
 }
 
 // getMinReadyDuration parses the min-ready-duration annotation (flap damping)
@@ -1374,6 +1891,60 @@ func (r *ServiceDirectorReconciler) getMinReadyDuration(svc *corev1.Service) tim
 	return duration
 }
 
+// getPodNotReadySince returns the time when the pod's Ready condition last turned non-True
+// Returns nil if the pod is Ready or the transition time is unknown
+func getPodNotReadySince(pod *corev1.Pod) *time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			if condition.Status == corev1.ConditionTrue || condition.LastTransitionTime.IsZero() {
+				return nil
+			}
+			return &condition.LastTransitionTime.Time
+		}
+	}
+	return nil
+}
+
+// getFailoverMinDelay parses the failover-min-delay annotation (failover grace period)
+func (r *ServiceDirectorReconciler) getFailoverMinDelay(svc *corev1.Service) time.Duration {
+	if svc.Annotations == nil {
+		return 0 // Default: fail over immediately
+	}
+	durationStr := svc.Annotations[AnnotationFailoverMinDelayService]
+	if durationStr == "" || durationStr == "0s" {
+		return 0
+	}
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil || duration < 0 {
+		// Invalid duration - fail over immediately
+		return 0
+	}
+	return duration
+}
+
+// getFailoverGraceRemaining returns how long a NotReady leader may keep leadership before failover.
+// Returns 0 if no grace applies: no failover-min-delay configured, the leader is terminating,
+// has no PodIP, has exited (Failed/Succeeded), or the NotReady transition time is unknown.
+func (r *ServiceDirectorReconciler) getFailoverGraceRemaining(svc *corev1.Service, leader *corev1.Pod) time.Duration {
+	failoverMinDelay := r.getFailoverMinDelay(svc)
+	if failoverMinDelay <= 0 {
+		return 0
+	}
+	if leader.DeletionTimestamp != nil || leader.Status.PodIP == "" ||
+		leader.Status.Phase == corev1.PodFailed || leader.Status.Phase == corev1.PodSucceeded {
+		return 0
+	}
+	notReadySince := getServiceEligibility(svc).notEligibleSince(leader)
+	if notReadySince == nil {
+		return 0
+	}
+	remaining := failoverMinDelay - time.Since(*notReadySince)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
 // getLeaderServiceName determines the leader service name
 // Validates that the resulting name is a valid Kubernetes resource name
 func (r *ServiceDirectorReconciler) getLeaderServiceName(svc *corev1.Service) string {
@@ -1412,7 +1983,8 @@ func (r *ServiceDirectorReconciler) getLeaderServiceName(svc *corev1.Service) st
 }
 
 // SetupWithManager sets up the ServiceDirectorReconciler with the manager
-// Pod watch predicates filter to meaningful transitions only (Ready, deletionTimestamp, podIP, phase)
+// Pod watch predicates filter to meaningful transitions only (Ready, deletionTimestamp, podIP, leader priority, leader label, phase)
+// Node watch predicates filter to labels, cordon state and taints
 func (r *ServiceDirectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Pod watch predicate - only react to meaningful transitions
 	podPredicate := predicate.Funcs{
@@ -1435,6 +2007,22 @@ func (r *ServiceDirectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return true
 			}
 
+			// 1b. Container readiness or Running phase changed (zen-lead.io/eligibility modes)
+			if containerReadinessChanged(oldPod, newPod) ||
+				(oldPod.Status.Phase == corev1.PodRunning) != (newPod.Status.Phase == corev1.PodRunning) {
+				return true
+			}
+
+			// 1c. Any pod condition changed (zen-lead.io/required-condition, e.g. readiness gates)
+			if podConditionsChanged(oldPod, newPod) {
+				return true
+			}
+
+			// 1d. Leader label moved by an external tool (zen-lead.io/leader-label)
+			if r.leaderLabelChanged(oldPod, newPod) {
+				return true
+			}
+
 			// 2. DeletionTimestamp became non-nil
 			oldDeleting := oldPod.DeletionTimestamp != nil
 			newDeleting := newPod.DeletionTimestamp != nil
@@ -1442,14 +2030,19 @@ func (r *ServiceDirectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return true
 			}
 
-			// 3. PodIP changed (empty ↔ assigned)
+			// 3. PodIP changed (empty ↔ assigned) or a dual-stack address was added
 			oldIP := oldPod.Status.PodIP
 			newIP := newPod.Status.PodIP
-			if oldIP != newIP {
+			if oldIP != newIP || !slices.Equal(oldPod.Status.PodIPs, newPod.Status.PodIPs) {
 				return true
 			}
 
-			// 4. Phase changed to Failed/Succeeded
+			// 4. Leader priority changed (annotation or label)
+			if getPodLeaderPriority(oldPod) != getPodLeaderPriority(newPod) {
+				return true
+			}
+
+			// 5. Phase changed to Failed/Succeeded
 			if (oldPod.Status.Phase == corev1.PodFailed || oldPod.Status.Phase == corev1.PodSucceeded) &&
 				(newPod.Status.Phase != oldPod.Status.Phase) {
 				return true
@@ -1482,7 +2075,33 @@ func (r *ServiceDirectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		}
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Index pods by node so Node changes can be mapped to the Services selecting them
+	if r.nodeAwareness {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeNameIndex, indexPodNodeName); err != nil {
+			return fmt.Errorf("failed to index pods by node name: %w", err)
+		}
+	}
+
+	// Index Services by followed Lease so Lease changes can be mapped to the Services following them
+	if r.followLease {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Service{}, serviceFollowLeaseIndex, indexServiceFollowLease); err != nil {
+			return fmt.Errorf("failed to index services by followed lease: %w", err)
+		}
+	}
+
+	// Node watch predicate - only react to changes that affect leader preference
+	nodePredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			return okOld && okNew && nodeLeadershipChanged(oldNode, newNode)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Service{}).
 		Watches(
 			&corev1.Pod{},
@@ -1492,7 +2111,24 @@ func (r *ServiceDirectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&discoveryv1.EndpointSlice{},
 			handler.EnqueueRequestsFromMapFunc(r.mapEndpointSliceToService),
-		).
+		)
+	// Nodes are only cached and watched with --enable-node-awareness (node RBAC is a separate opt-in)
+	if r.nodeAwareness {
+		b = b.Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNodeToService),
+			builder.WithPredicates(nodePredicate),
+		)
+	}
+	// Leases are only cached and watched with --enable-follow-lease (the informer is cluster-wide)
+	if r.followLease {
+		b = b.Watches(
+			&coordinationv1.Lease{},
+			handler.EnqueueRequestsFromMapFunc(r.mapLeaseToService),
+			builder.WithPredicates(leasePredicate),
+		)
+	}
+	return b.
 		// Bound reconcile concurrency + Safety resync handled by informer cache (default 10m)
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.maxConcurrentReconciles, // Configurable concurrency limit
@@ -1660,9 +2296,10 @@ func (r *ServiceDirectorReconciler) updateOptedInServicesCacheLocked(ctx context
 		selector := labels.SelectorFromSet(svc.Spec.Selector)
 		now := time.Now()
 		cached = append(cached, &cachedService{
-			name:       svc.Name,
-			selector:   selector,
-			lastAccess: now, // Initialize access time
+			name:        svc.Name,
+			selector:    selector,
+			leaderLabel: cachedLeaderLabel(svc),
+			lastAccess:  now, // Initialize access time
 		})
 	}
 
@@ -1746,14 +2383,16 @@ func (r *ServiceDirectorReconciler) updateOptedInServicesCacheForService(svc *co
 		if cachedSvc.name == svc.Name {
 			// Update existing (update access time for LRU)
 			cached[i].selector = selector
+			cached[i].leaderLabel = cachedLeaderLabel(svc)
 			cached[i].lastAccess = now
 			return
 		}
 	}
 	// Add new
 	r.optedInServicesCache[svc.Namespace] = append(cached, &cachedService{
-		name:       svc.Name,
-		selector:   selector,
-		lastAccess: now,
+		name:        svc.Name,
+		selector:    selector,
+		leaderLabel: cachedLeaderLabel(svc),
+		lastAccess:  now,
 	})
 }
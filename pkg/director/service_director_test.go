@@ -2637,3 +2637,268 @@ func TestServiceDirectorReconciler_SetupWithManager(t *testing.T) {
 	// 3. r.SetupWithManager(mgr)
 	// For now, we verify SetupWithManager exists and has correct signature via compilation
 }
+
+func TestGetPodLeaderPriority(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		labels      map[string]string
+		expected    int64
+	}{
+		{name: "no priority", expected: 0},
+		{name: "annotation", annotations: map[string]string{AnnotationLeaderPriorityPod: "10"}, expected: 10},
+		{name: "label", labels: map[string]string{AnnotationLeaderPriorityPod: "5"}, expected: 5},
+		{name: "annotation wins over label", annotations: map[string]string{AnnotationLeaderPriorityPod: "1"}, labels: map[string]string{AnnotationLeaderPriorityPod: "5"}, expected: 1},
+		{name: "negative", annotations: map[string]string{AnnotationLeaderPriorityPod: "-3"}, expected: -3},
+		{name: "invalid", annotations: map[string]string{AnnotationLeaderPriorityPod: "high"}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations, Labels: tt.labels}}
+			if got := getPodLeaderPriority(pod); got != tt.expected {
+				t.Errorf("getPodLeaderPriority() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_SelectLeaderPod_Priority(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	discoveryv1.AddToScheme(scheme)
+
+	newPod := func(name string, age time.Duration, priority string) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				UID:               types.UID(name + "-uid"),
+				Labels:            map[string]string{"app": "my-app"},
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				PodIP:      "10.0.0.1",
+			},
+		}
+		if priority != "" {
+			pod.Annotations = map[string]string{AnnotationLeaderPriorityPod: priority}
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name          string
+		preemption    bool
+		stickyLeader  string
+		expectedPod   string
+		pods          []corev1.Pod
+		expectPreempt bool
+	}{
+		{
+			name:        "highest priority wins over older pod",
+			pods:        []corev1.Pod{newPod("pod-old", time.Hour, ""), newPod("pod-big", time.Minute, "100")},
+			expectedPod: "pod-big",
+		},
+		{
+			name:        "equal priority falls back to creation time",
+			pods:        []corev1.Pod{newPod("pod-new", time.Minute, "10"), newPod("pod-old", time.Hour, "10")},
+			expectedPod: "pod-old",
+		},
+		{
+			name:         "sticky leader kept without preemption",
+			pods:         []corev1.Pod{newPod("pod-old", time.Hour, ""), newPod("pod-big", time.Minute, "100")},
+			stickyLeader: "pod-old",
+			expectedPod:  "pod-old",
+		},
+		{
+			name:          "higher priority preempts sticky leader when enabled",
+			pods:          []corev1.Pod{newPod("pod-old", time.Hour, ""), newPod("pod-big", time.Minute, "100")},
+			stickyLeader:  "pod-old",
+			preemption:    true,
+			expectedPod:   "pod-big",
+			expectPreempt: true,
+		},
+		{
+			name:         "equal priority does not preempt",
+			pods:         []corev1.Pod{newPod("pod-a", time.Hour, "5"), newPod("pod-b", 2*time.Hour, "5")},
+			stickyLeader: "pod-a",
+			preemption:   true,
+			expectedPod:  "pod-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-service",
+					Namespace:   "default",
+					Annotations: map[string]string{AnnotationEnabledService: "true"},
+				},
+			}
+			if tt.preemption {
+				svc.Annotations[AnnotationPriorityPreemptionService] = "true"
+			}
+			objs := []client.Object{svc}
+			if tt.stickyLeader != "" {
+				objs = append(objs, &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{Name: svc.Name + ServiceSuffixService, Namespace: "default"},
+					Endpoints: []discoveryv1.Endpoint{{
+						Addresses: []string{"10.0.0.1"},
+						TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: tt.stickyLeader, UID: types.UID(tt.stickyLeader + "-uid")},
+					}},
+				})
+			}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			leader := r.selectLeaderPod(context.Background(), svc, tt.pods, false, logger)
+			if leader == nil || leader.Name != tt.expectedPod {
+				t.Fatalf("selectLeaderPod() = %v, expected %s", leader, tt.expectedPod)
+			}
+
+			gotPreempt := false
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "LeaderPreempted") {
+					gotPreempt = true
+				}
+			}
+			if gotPreempt != tt.expectPreempt {
+				t.Errorf("LeaderPreempted event = %v, expected %v", gotPreempt, tt.expectPreempt)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_FailoverMinDelay(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	discoveryv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		notReadyFor    time.Duration
+		terminating    bool
+		expectedLeader string
+		expectRequeue  bool
+		expectEvent    string
+	}{
+		{name: "leader kept during grace period", notReadyFor: 5 * time.Second, expectedLeader: "pod-1", expectRequeue: true, expectEvent: "FailoverDelayed"},
+		{name: "failover after grace period", notReadyFor: 2 * time.Minute, expectedLeader: "pod-2", expectEvent: "FailoverGraceExpired"},
+		{name: "terminating leader fails over immediately", notReadyFor: 5 * time.Second, terminating: true, expectedLeader: "pod-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-service",
+					Namespace: "default",
+					UID:       "svc-uid",
+					Annotations: map[string]string{
+						AnnotationEnabledService:          "true",
+						AnnotationFailoverMinDelayService: "1m",
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Selector: map[string]string{"app": "my-app"},
+					Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+				},
+			}
+			leader := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod-1",
+					Namespace:         "default",
+					UID:               "pod-1-uid",
+					Labels:            map[string]string{"app": "my-app"},
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{
+						Type:               corev1.PodReady,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-tt.notReadyFor)),
+					}},
+					PodIP: "10.0.0.1",
+				},
+			}
+			if tt.terminating {
+				now := metav1.Now()
+				leader.DeletionTimestamp = &now
+				leader.Finalizers = []string{"test/finalizer"}
+			}
+			standby := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod-2",
+					Namespace:         "default",
+					UID:               "pod-2-uid",
+					Labels:            map[string]string{"app": "my-app"},
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+				},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+					PodIP:      "10.0.0.2",
+				},
+			}
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{Name: service.Name + ServiceSuffixService, Namespace: "default"},
+				Endpoints: []discoveryv1.Endpoint{{
+					Addresses: []string{"10.0.0.1"},
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "pod-1", UID: "pod-1-uid"},
+				}},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(service, leader, standby, endpointSlice).
+				Build()
+			eventRecorder := record.NewFakeRecorder(20)
+			r := &ServiceDirectorReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: service.Name, Namespace: service.Namespace}})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() RequeueAfter = %v, expected requeue %v", result.RequeueAfter, tt.expectRequeue)
+			}
+
+			slice := &discoveryv1.EndpointSlice{}
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: endpointSlice.Name, Namespace: "default"}, slice); err != nil {
+				t.Fatalf("Failed to get EndpointSlice: %v", err)
+			}
+			if len(slice.Endpoints) != 1 || slice.Endpoints[0].TargetRef == nil || slice.Endpoints[0].TargetRef.Name != tt.expectedLeader {
+				t.Errorf("EndpointSlice endpoints = %+v, expected leader %s", slice.Endpoints, tt.expectedLeader)
+			}
+
+			if tt.expectEvent != "" {
+				found := false
+				for len(eventRecorder.Events) > 0 {
+					if strings.Contains(<-eventRecorder.Events, tt.expectEvent) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("expected %s event", tt.expectEvent)
+				}
+			}
+		})
+	}
+}
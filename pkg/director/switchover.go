@@ -0,0 +1,303 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/retry"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationSwitchoverRequestService triggers a planned leadership handover; each new request id
+	// starts one switchover (e.g. "upgrade-2025-06-01")
+	AnnotationSwitchoverRequestService = "zen-lead.io/switchover-request"
+	// AnnotationSwitchoverTargetService optionally names the pod to hand over to (default: next ranked candidate)
+	AnnotationSwitchoverTargetService = "zen-lead.io/switchover-target"
+	// AnnotationSwitchoverDrainService sets how long the old leader drains before the new one is published (default 10s)
+	AnnotationSwitchoverDrainService = "zen-lead.io/switchover-drain"
+
+	defaultSwitchoverDrain = 10 * time.Second
+
+	// Switchover progress, recorded on the leader Service
+	AnnotationSwitchoverID         = "zen-lead.io/switchover-id"
+	AnnotationSwitchoverPhase      = "zen-lead.io/switchover-phase"
+	AnnotationSwitchoverFrom       = "zen-lead.io/switchover-from"
+	AnnotationSwitchoverTo         = "zen-lead.io/switchover-to"
+	AnnotationSwitchoverToUID      = "zen-lead.io/switchover-to-uid"
+	AnnotationSwitchoverDrainUntil = "zen-lead.io/switchover-drain-until"
+	AnnotationSwitchoverMessage    = "zen-lead.io/switchover-message"
+
+	// Switchover phases
+	SwitchoverPhaseDraining  = "Draining"
+	SwitchoverPhaseCompleted = "Completed"
+	SwitchoverPhaseFailed    = "Failed"
+)
+
+// switchoverDecision is the outcome of a switchover: either the old leader is still draining (until
+// requeueAfter), or the new leader is published, or a completed switchover's target still leads
+type switchoverDecision struct {
+	draining     *corev1.Pod
+	leader       *corev1.Pod
+	requeueAfter time.Duration
+
+	// handedOver is the target of a completed switchover that is still the leader. Selection keeps it
+	// (regardless of stickiness and priority preemption) until leadership next changes.
+	handedOver *corev1.Pod
+}
+
+// getSwitchoverDrain parses zen-lead.io/switchover-drain (default 10s)
+func getSwitchoverDrain(svc *corev1.Service) (time.Duration, error) {
+	val := strings.TrimSpace(svc.Annotations[AnnotationSwitchoverDrainService])
+	if val == "" {
+		return defaultSwitchoverDrain, nil
+	}
+	drain, err := time.ParseDuration(val)
+	if err != nil || drain < 0 {
+		return 0, fmt.Errorf("invalid %s %q", AnnotationSwitchoverDrainService, val)
+	}
+	return drain, nil
+}
+
+// externalLeaderSource returns the annotation that lets something outside zen-lead decide the leader
+// (a switchover cannot move leadership there), or "" if zen-lead decides
+func externalLeaderSource(svc *corev1.Service) string {
+	switch {
+	case hasRoleProbe(svc):
+		return AnnotationRoleProbeService
+	case getFollowLease(svc) != "":
+		return AnnotationFollowLeaseService
+	case hasLeaderLabel(svc):
+		return AnnotationLeaderLabelService
+	}
+	return ""
+}
+
+// reconcileSwitchover advances the switchover requested via zen-lead.io/switchover-request. Returns nil
+// when no switchover is in progress (none requested, or the request already completed or failed) and
+// no completed switchover's target is still the leader.
+// Progress is recorded on the leader Service and reported with events keyed by the request id.
+func (r *ServiceDirectorReconciler) reconcileSwitchover(ctx context.Context, svc *corev1.Service, currentLeader *corev1.Pod, candidates []corev1.Pod, logger *sdklog.Logger) (*switchoverDecision, error) {
+	id := strings.TrimSpace(svc.Annotations[AnnotationSwitchoverRequestService])
+
+	leaderService := &corev1.Service{}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Get(ctx, types.NamespacedName{Name: r.getLeaderServiceName(svc), Namespace: svc.Namespace}, leaderService)
+	}, r.Metrics, svc.Namespace, svc.Name, "get_leader_service_switchover"); err != nil {
+		// No leader Service yet means no leader to hand over from - nothing to do until one exists
+		return nil, client.IgnoreNotFound(err)
+	}
+	status := leaderService.Annotations
+	if status[AnnotationSwitchoverPhase] == SwitchoverPhaseCompleted && (id == "" || status[AnnotationSwitchoverID] == id) {
+		// The handover is remembered (also after the request is removed) while its target still leads,
+		// so neither stickiness being off nor priority preemption hands leadership straight back
+		if currentLeader != nil && string(currentLeader.UID) == status[AnnotationSwitchoverToUID] {
+			return &switchoverDecision{handedOver: currentLeader}, nil
+		}
+		return nil, nil
+	}
+	if id == "" || (status[AnnotationSwitchoverID] == id && status[AnnotationSwitchoverPhase] != SwitchoverPhaseDraining) {
+		return nil, nil
+	}
+
+	fail := func(message string) (*switchoverDecision, error) {
+		logger.Info("Switchover failed", sdklog.Operation("switchover"), sdklog.String("id", id), sdklog.String("reason", message))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "SwitchoverFailed", fmt.Sprintf("Switchover %s failed: %s", id, message))
+		return nil, r.setSwitchoverStatus(ctx, svc, leaderService, map[string]string{
+			AnnotationSwitchoverID:         id,
+			AnnotationSwitchoverPhase:      SwitchoverPhaseFailed,
+			AnnotationSwitchoverMessage:    message,
+			AnnotationSwitchoverDrainUntil: "",
+		})
+	}
+
+	if status[AnnotationSwitchoverID] != id {
+		// New request - validate and start draining the current leader
+		if source := externalLeaderSource(svc); source != "" {
+			return fail(fmt.Sprintf("leadership is decided by %s", source))
+		}
+		if currentLeader == nil {
+			return fail("there is no current leader")
+		}
+		target, reason := selectSwitchoverTarget(svc, currentLeader, candidates)
+		if target == nil {
+			return fail(reason)
+		}
+		drain, err := getSwitchoverDrain(svc)
+		if err != nil {
+			return fail(err.Error())
+		}
+
+		drainUntil := time.Now().Add(drain)
+		status = map[string]string{
+			AnnotationSwitchoverID:         id,
+			AnnotationSwitchoverPhase:      SwitchoverPhaseDraining,
+			AnnotationSwitchoverFrom:       currentLeader.Name,
+			AnnotationSwitchoverTo:         target.Name,
+			AnnotationSwitchoverToUID:      string(target.UID),
+			AnnotationSwitchoverDrainUntil: drainUntil.Format(time.RFC3339Nano),
+			AnnotationSwitchoverMessage:    fmt.Sprintf("draining %s for %s before handing over to %s", currentLeader.Name, drain, target.Name),
+		}
+		if err := r.setSwitchoverStatus(ctx, svc, leaderService, status); err != nil {
+			return nil, err
+		}
+		logger.Info("Switchover started", sdklog.Operation("switchover"), sdklog.String("id", id),
+			sdklog.String("from", currentLeader.Name), sdklog.String("to", target.Name), sdklog.Duration("drain", drain))
+		r.Recorder.Event(svc, corev1.EventTypeNormal, "SwitchoverStarted",
+			fmt.Sprintf("Switchover %s: draining leader %s for %s before handing over to %s", id, currentLeader.Name, drain, target.Name))
+	}
+
+	// Draining - the target must stay eligible until it is published
+	var target *corev1.Pod
+	for i := range candidates {
+		if string(candidates[i].UID) == status[AnnotationSwitchoverToUID] {
+			target = &candidates[i]
+		}
+	}
+	if target == nil {
+		return fail(fmt.Sprintf("target %s became unavailable while draining", status[AnnotationSwitchoverTo]))
+	}
+	drainUntil, err := time.Parse(time.RFC3339Nano, status[AnnotationSwitchoverDrainUntil])
+	if err != nil {
+		return fail(fmt.Sprintf("invalid %s %q", AnnotationSwitchoverDrainUntil, status[AnnotationSwitchoverDrainUntil]))
+	}
+	if remaining := time.Until(drainUntil); remaining > 0 && currentLeader != nil && currentLeader.Name == status[AnnotationSwitchoverFrom] {
+		return &switchoverDecision{draining: currentLeader, requeueAfter: remaining}, nil
+	}
+
+	// Drain complete (or the old leader is already gone) - publish the new leader
+	if err := r.setSwitchoverStatus(ctx, svc, leaderService, map[string]string{
+		AnnotationSwitchoverPhase:      SwitchoverPhaseCompleted,
+		AnnotationSwitchoverDrainUntil: "",
+		AnnotationSwitchoverMessage:    fmt.Sprintf("leadership handed over from %s to %s", status[AnnotationSwitchoverFrom], target.Name),
+	}); err != nil {
+		return nil, err
+	}
+	logger.Info("Switchover completed", sdklog.Operation("switchover"), sdklog.String("id", id), sdklog.String("leader", target.Name))
+	r.Recorder.Event(svc, corev1.EventTypeNormal, "SwitchoverCompleted",
+		fmt.Sprintf("Switchover %s: leadership handed over from %s to %s", id, status[AnnotationSwitchoverFrom], target.Name))
+	return &switchoverDecision{leader: target}, nil
+}
+
+// selectSwitchoverTarget returns the requested target (zen-lead.io/switchover-target) or the best-ranked
+// candidate other than the current leader, with the reason when there is none
+func selectSwitchoverTarget(svc *corev1.Service, currentLeader *corev1.Pod, candidates []corev1.Pod) (*corev1.Pod, string) {
+	requested := strings.TrimSpace(svc.Annotations[AnnotationSwitchoverTargetService])
+	if requested == currentLeader.Name {
+		return nil, fmt.Sprintf("target %s is already the leader", requested)
+	}
+	for i := range candidates {
+		if candidates[i].UID == currentLeader.UID {
+			continue
+		}
+		if requested == "" || candidates[i].Name == requested {
+			return &candidates[i], ""
+		}
+	}
+	if requested != "" {
+		return nil, fmt.Sprintf("target %s is not an eligible pod", requested)
+	}
+	return nil, "no other eligible pod to hand over to"
+}
+
+// setSwitchoverStatus patches switchover progress annotations on the leader Service ("" removes a key)
+func (r *ServiceDirectorReconciler) setSwitchoverStatus(ctx context.Context, svc, leaderService *corev1.Service, values map[string]string) error {
+	original := leaderService.DeepCopy()
+	if leaderService.Annotations == nil {
+		leaderService.Annotations = make(map[string]string)
+	}
+	for k, v := range values {
+		if v == "" {
+			delete(leaderService.Annotations, k)
+		} else {
+			leaderService.Annotations[k] = v
+		}
+	}
+	if err := retryDoWithMetrics(ctx, retry.DefaultConfig(), func() error {
+		return r.Patch(ctx, leaderService, client.MergeFrom(original))
+	}, r.Metrics, svc.Namespace, svc.Name, "patch_switchover_status"); err != nil {
+		return fmt.Errorf("failed to record switchover status on %s/%s: %w", leaderService.Namespace, leaderService.Name, err)
+	}
+	return nil
+}
+
+// drainLeaderEndpoint marks the draining leader's endpoints (in every IP family slice of the leader Service
+// and its aliases) not ready but serving and terminating, so new connections stop while existing ones finish
+// (kube-proxy and most data planes honour this)
+func (r *ServiceDirectorReconciler) drainLeaderEndpoint(ctx context.Context, svc *corev1.Service, pod *corev1.Pod, logger *sdklog.Logger) error {
+	endpointSlices, err := r.listEndpointSlices(ctx, svc, r.getLeaderServiceName(svc), "list_endpointslices_drain")
+	if err != nil {
+		return err
+	}
+	aliasServices, err := r.listLeaderServiceAliases(ctx, svc.Namespace, svc.Name, "list_alias_services_drain")
+	if err != nil {
+		return err
+	}
+	for i := range aliasServices {
+		aliasSlices, err := r.listEndpointSlices(ctx, svc, aliasServices[i].Name, "list_endpointslices_drain")
+		if err != nil {
+			return err
+		}
+		endpointSlices = append(endpointSlices, aliasSlices...)
+	}
+
+	drained := false
+	for i := range endpointSlices {
+		endpointSlice := &endpointSlices[i]
+		original := endpointSlice.DeepCopy()
+		changed := false
+		for j := range endpointSlice.Endpoints {
+			endpoint := &endpointSlice.Endpoints[j]
+			if endpoint.TargetRef == nil || endpoint.TargetRef.UID != pod.UID {
+				continue
+			}
+			conditions := endpoint.Conditions
+			if conditions.Ready != nil && !*conditions.Ready &&
+				conditions.Serving != nil && *conditions.Serving &&
+				conditions.Terminating != nil && *conditions.Terminating {
+				continue
+			}
+			ready, serving, terminating := false, true, true
+			endpoint.Conditions = discoveryv1.EndpointConditions{Ready: &ready, Serving: &serving, Terminating: &terminating}
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if err := retryDoWithMetrics(ctx, r.fastRetryConfig, func() error {
+			return r.Patch(ctx, endpointSlice, client.MergeFrom(original))
+		}, r.Metrics, svc.Namespace, svc.Name, "patch_endpointslice_drain"); err != nil {
+			if r.Metrics != nil {
+				r.Metrics.RecordEndpointWriteError(svc.Namespace, svc.Name)
+			}
+			return fmt.Errorf("failed to drain leader endpoint in %s/%s: %w", endpointSlice.Namespace, endpointSlice.Name, err)
+		}
+		drained = true
+	}
+	if drained {
+		logger.Info("Draining leader endpoint", sdklog.Operation("switchover"), sdklog.String("pod", pod.Name))
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceDirectorReconciler_ReconcileSwitchover_Start(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	leader := newReadyPod("pod-a", 2*time.Hour)
+	notReady := newReadyPod("pod-c", time.Minute)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		currentLeader *corev1.Pod
+		pods          []corev1.Pod
+		expectedPhase string
+		expectedTo    string
+		expectEvent   string
+	}{
+		{
+			name:          "next ranked candidate",
+			annotations:   map[string]string{AnnotationSwitchoverRequestService: "sw-1"},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader, newReadyPod("pod-b", time.Hour)},
+			expectedPhase: SwitchoverPhaseDraining,
+			expectedTo:    "pod-b",
+			expectEvent:   "SwitchoverStarted",
+		},
+		{
+			name: "requested target",
+			annotations: map[string]string{
+				AnnotationSwitchoverRequestService: "sw-1",
+				AnnotationSwitchoverTargetService:  "pod-c",
+			},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader, newReadyPod("pod-b", time.Hour), newReadyPod("pod-c", time.Minute)},
+			expectedPhase: SwitchoverPhaseDraining,
+			expectedTo:    "pod-c",
+			expectEvent:   "SwitchoverStarted",
+		},
+		{
+			name: "target not eligible",
+			annotations: map[string]string{
+				AnnotationSwitchoverRequestService: "sw-1",
+				AnnotationSwitchoverTargetService:  "pod-c",
+			},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader, notReady},
+			expectedPhase: SwitchoverPhaseFailed,
+			expectEvent:   "target pod-c is not an eligible pod",
+		},
+		{
+			name: "target is the leader",
+			annotations: map[string]string{
+				AnnotationSwitchoverRequestService: "sw-1",
+				AnnotationSwitchoverTargetService:  "pod-a",
+			},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader, newReadyPod("pod-b", time.Hour)},
+			expectedPhase: SwitchoverPhaseFailed,
+			expectEvent:   "already the leader",
+		},
+		{
+			name:          "no other candidate",
+			annotations:   map[string]string{AnnotationSwitchoverRequestService: "sw-1"},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader},
+			expectedPhase: SwitchoverPhaseFailed,
+			expectEvent:   "SwitchoverFailed",
+		},
+		{
+			name:          "no current leader",
+			annotations:   map[string]string{AnnotationSwitchoverRequestService: "sw-1"},
+			pods:          []corev1.Pod{leader, newReadyPod("pod-b", time.Hour)},
+			expectedPhase: SwitchoverPhaseFailed,
+			expectEvent:   "there is no current leader",
+		},
+		{
+			name: "leader decided by an external source",
+			annotations: map[string]string{
+				AnnotationSwitchoverRequestService: "sw-1",
+				AnnotationLeaderLabelService:       "role=primary",
+			},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader, newReadyPod("pod-b", time.Hour)},
+			expectedPhase: SwitchoverPhaseFailed,
+			expectEvent:   AnnotationLeaderLabelService,
+		},
+		{
+			name: "invalid drain",
+			annotations: map[string]string{
+				AnnotationSwitchoverRequestService: "sw-1",
+				AnnotationSwitchoverDrainService:   "soon",
+			},
+			currentLeader: &leader,
+			pods:          []corev1.Pod{leader, newReadyPod("pod-b", time.Hour)},
+			expectedPhase: SwitchoverPhaseFailed,
+			expectEvent:   "invalid zen-lead.io/switchover-drain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{AnnotationEnabledService: "true"}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: annotations}}
+			leaderService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-service-leader", Namespace: "default"}}
+
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, leaderService).Build(),
+				Scheme:   scheme,
+				Recorder: eventRecorder,
+				Metrics:  metrics.NewRecorder(),
+			}
+
+			logger := packageLogger.WithContext(context.Background())
+			candidates := r.rankLeaderCandidates(context.Background(), svc, tt.pods, logger)
+			decision, err := r.reconcileSwitchover(context.Background(), svc, tt.currentLeader, candidates.ranked, logger)
+			if err != nil {
+				t.Fatalf("reconcileSwitchover() error = %v", err)
+			}
+			if tt.expectedPhase == SwitchoverPhaseDraining {
+				if decision == nil || decision.draining == nil || decision.draining.Name != "pod-a" {
+					t.Fatalf("reconcileSwitchover() = %+v, expected pod-a draining", decision)
+				}
+			} else if decision != nil {
+				t.Fatalf("reconcileSwitchover() = %+v, expected no switchover", decision)
+			}
+
+			status := &corev1.Service{}
+			if err := r.Get(context.Background(), types.NamespacedName{Name: "my-service-leader", Namespace: "default"}, status); err != nil {
+				t.Fatalf("failed to get leader service: %v", err)
+			}
+			if got := status.Annotations[AnnotationSwitchoverID]; got != "sw-1" {
+				t.Errorf("%s = %q, expected sw-1", AnnotationSwitchoverID, got)
+			}
+			if got := status.Annotations[AnnotationSwitchoverPhase]; got != tt.expectedPhase {
+				t.Errorf("%s = %q, expected %s", AnnotationSwitchoverPhase, got, tt.expectedPhase)
+			}
+			if got := status.Annotations[AnnotationSwitchoverTo]; tt.expectedTo != "" && got != tt.expectedTo {
+				t.Errorf("%s = %q, expected %s", AnnotationSwitchoverTo, got, tt.expectedTo)
+			}
+
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if strings.Contains(event, tt.expectEvent) && strings.Contains(event, "sw-1") {
+					gotEvent = true
+				}
+			}
+			if !gotEvent {
+				t.Errorf("expected %s event for sw-1", tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_Switchover(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-service",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationEnabledService: "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	eventRecorder := record.NewFakeRecorder(50)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	leaderKey := types.NamespacedName{Name: "my-service-leader", Namespace: "default"}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-a" {
+		t.Fatalf("leader endpoints = %v, expected [pod-a]", got)
+	}
+
+	// Request a switchover - pod-a drains (not ready, still serving) and the reconcile is requeued at the drain end
+	current := &corev1.Service{}
+	if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	current.Annotations[AnnotationSwitchoverRequestService] = "sw-1"
+	current.Annotations[AnnotationSwitchoverDrainService] = "30s"
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter <= 25*time.Second || result.RequeueAfter > 30*time.Second {
+		t.Errorf("RequeueAfter = %v, expected the remaining drain time", result.RequeueAfter)
+	}
+	slice := &discoveryv1.EndpointSlice{}
+	if err := r.Get(context.Background(), leaderKey, slice); err != nil {
+		t.Fatalf("failed to get EndpointSlice: %v", err)
+	}
+	if len(slice.Endpoints) != 1 || slice.Endpoints[0].TargetRef.Name != "pod-a" {
+		t.Fatalf("leader endpoints while draining = %+v, expected pod-a", slice.Endpoints)
+	}
+	conditions := slice.Endpoints[0].Conditions
+	if conditions.Ready == nil || *conditions.Ready ||
+		conditions.Serving == nil || !*conditions.Serving ||
+		conditions.Terminating == nil || !*conditions.Terminating {
+		t.Errorf("draining endpoint conditions = %+v, expected not ready, serving and terminating", conditions)
+	}
+
+	// Drain period ends - pod-b is published and the switchover completes
+	status := &corev1.Service{}
+	if err := r.Get(context.Background(), leaderKey, status); err != nil {
+		t.Fatalf("failed to get leader service: %v", err)
+	}
+	if status.Annotations[AnnotationSwitchoverPhase] != SwitchoverPhaseDraining {
+		t.Fatalf("%s = %q, expected %s", AnnotationSwitchoverPhase, status.Annotations[AnnotationSwitchoverPhase], SwitchoverPhaseDraining)
+	}
+	status.Annotations[AnnotationSwitchoverDrainUntil] = time.Now().Add(-time.Second).Format(time.RFC3339Nano)
+	if err := r.Update(context.Background(), status); err != nil {
+		t.Fatalf("failed to update leader service: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints after drain = %v, expected [pod-b]", got)
+	}
+	if err := r.Get(context.Background(), leaderKey, status); err != nil {
+		t.Fatalf("failed to get leader service: %v", err)
+	}
+	if got := status.Annotations[AnnotationSwitchoverPhase]; got != SwitchoverPhaseCompleted {
+		t.Errorf("%s = %q, expected %s", AnnotationSwitchoverPhase, got, SwitchoverPhaseCompleted)
+	}
+	if got := status.Annotations[AnnotationSwitchoverFrom] + "->" + status.Annotations[AnnotationSwitchoverTo]; got != "pod-a->pod-b" {
+		t.Errorf("switchover from/to = %s, expected pod-a->pod-b", got)
+	}
+
+	// A completed request is not repeated; the new leader stays through stickiness
+	for len(eventRecorder.Events) > 0 {
+		<-eventRecorder.Events
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if got := activeEndpointNames(t, r); strings.Join(got, ",") != "pod-b" {
+		t.Fatalf("leader endpoints after completion = %v, expected [pod-b]", got)
+	}
+	for len(eventRecorder.Events) > 0 {
+		if event := <-eventRecorder.Events; strings.Contains(event, "Switchover") {
+			t.Errorf("unexpected event after completion: %s", event)
+		}
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_SwitchoverWithoutStickiness(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-service",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationEnabledService:           "true",
+				AnnotationStickyService:            "false",
+				AnnotationSwitchoverRequestService: "sw-1",
+				AnnotationSwitchoverDrainService:   "0s",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "my-app"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	podA := newActivePod("pod-a", "10.0.0.1", 2*time.Hour, 8080)
+	podB := newActivePod("pod-b", "10.0.0.2", time.Hour, 8080)
+
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, podA, podB).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(100),
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+	expectLeader := func(expected, when string) {
+		t.Helper()
+		if got := activeEndpointNames(t, r); strings.Join(got, ",") != expected {
+			t.Fatalf("leader endpoints %s = %v, expected [%s]", when, got, expected)
+		}
+	}
+
+	// No leader Service yet - the request waits; the oldest pod leads
+	reconcile()
+	expectLeader("pod-a", "initially")
+
+	// Without a drain period the switchover starts and completes in one reconcile
+	reconcile()
+	expectLeader("pod-b", "after switchover")
+
+	// Without stickiness the strategy prefers pod-a, but the handover is remembered
+	reconcile()
+	expectLeader("pod-b", "after completion")
+
+	// Also once the request annotation is removed
+	current := &corev1.Service{}
+	if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	delete(current.Annotations, AnnotationSwitchoverRequestService)
+	if err := r.Update(context.Background(), current); err != nil {
+		t.Fatalf("failed to update service: %v", err)
+	}
+	reconcile()
+	expectLeader("pod-b", "after the request is removed")
+
+	// The target fails - leadership moves on, and the handover no longer applies once it is back
+	setPodReady := func(pod *corev1.Pod, status corev1.ConditionStatus) {
+		t.Helper()
+		updated := &corev1.Pod{}
+		if err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), updated); err != nil {
+			t.Fatalf("failed to get pod: %v", err)
+		}
+		updated.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		if err := r.Status().Update(context.Background(), updated); err != nil {
+			t.Fatalf("failed to update pod status: %v", err)
+		}
+	}
+	setPodReady(podB, corev1.ConditionFalse)
+	reconcile()
+	expectLeader("pod-a", "after the target failed")
+	setPodReady(podB, corev1.ConditionTrue)
+	reconcile()
+	expectLeader("pod-a", "after the target recovered")
+}
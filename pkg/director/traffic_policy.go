@@ -0,0 +1,309 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	sdklog "github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnotationLeaderSessionAffinityService overrides the leader Service's sessionAffinity (None|ClientIP)
+	AnnotationLeaderSessionAffinityService = "zen-lead.io/leader-session-affinity"
+	// AnnotationLeaderSessionAffinityTimeoutService overrides the ClientIP affinity timeout in seconds (1-86400)
+	AnnotationLeaderSessionAffinityTimeoutService = "zen-lead.io/leader-session-affinity-timeout"
+	// AnnotationLeaderExternalTrafficPolicyService overrides the leader Service's externalTrafficPolicy (Cluster|Local)
+	AnnotationLeaderExternalTrafficPolicyService = "zen-lead.io/leader-external-traffic-policy"
+	// AnnotationLeaderInternalTrafficPolicyService overrides the leader Service's internalTrafficPolicy (Cluster|Local)
+	AnnotationLeaderInternalTrafficPolicyService = "zen-lead.io/leader-internal-traffic-policy"
+	// AnnotationLeaderLoadBalancerClassService overrides the leader Service's loadBalancerClass
+	AnnotationLeaderLoadBalancerClassService = "zen-lead.io/leader-load-balancer-class"
+	// AnnotationLeaderLoadBalancerSourceRangesService overrides the leader Service's loadBalancerSourceRanges
+	// (comma-separated CIDRs)
+	AnnotationLeaderLoadBalancerSourceRangesService = "zen-lead.io/leader-load-balancer-source-ranges"
+	// AnnotationLeaderPublishNotReadyAddressesService overrides the leader Service's publishNotReadyAddresses
+	AnnotationLeaderPublishNotReadyAddressesService = "zen-lead.io/leader-publish-not-ready-addresses"
+
+	// AnnotationLastAppliedTrafficPolicy is kept on the leader Service: the mirrored traffic-policy values it
+	// had after the last reconcile (JSON, by field name). Only a leader Service that no longer matches them
+	// has drifted; source Service and override changes are ordinary updates.
+	AnnotationLastAppliedTrafficPolicy = "zen-lead.io/last-applied-traffic-policy"
+
+	maxSessionAffinityTimeoutSeconds = 86400
+)
+
+// mirroredField is a traffic-policy field mirrored from the source Service onto the leader Service
+type mirroredField struct {
+	name  string
+	value func(spec *corev1.ServiceSpec) string
+	set   func(dst, src *corev1.ServiceSpec)
+}
+
+// mirroredTrafficFields are the traffic-policy fields of the leader Service kept in sync with the source
+var mirroredTrafficFields = []mirroredField{
+	{
+		name:  "sessionAffinity",
+		value: func(spec *corev1.ServiceSpec) string { return string(spec.SessionAffinity) },
+		set:   func(dst, src *corev1.ServiceSpec) { dst.SessionAffinity = src.SessionAffinity },
+	},
+	{
+		name: "sessionAffinityConfig",
+		value: func(spec *corev1.ServiceSpec) string {
+			if spec.SessionAffinityConfig == nil || spec.SessionAffinityConfig.ClientIP == nil || spec.SessionAffinityConfig.ClientIP.TimeoutSeconds == nil {
+				return ""
+			}
+			return strconv.Itoa(int(*spec.SessionAffinityConfig.ClientIP.TimeoutSeconds)) + "s"
+		},
+		set: func(dst, src *corev1.ServiceSpec) { dst.SessionAffinityConfig = src.SessionAffinityConfig.DeepCopy() },
+	},
+	{
+		name:  "externalTrafficPolicy",
+		value: func(spec *corev1.ServiceSpec) string { return string(spec.ExternalTrafficPolicy) },
+		set:   func(dst, src *corev1.ServiceSpec) { dst.ExternalTrafficPolicy = src.ExternalTrafficPolicy },
+	},
+	{
+		name: "internalTrafficPolicy",
+		value: func(spec *corev1.ServiceSpec) string {
+			if spec.InternalTrafficPolicy == nil {
+				return ""
+			}
+			return string(*spec.InternalTrafficPolicy)
+		},
+		set: func(dst, src *corev1.ServiceSpec) {
+			dst.InternalTrafficPolicy = nil
+			if src.InternalTrafficPolicy != nil {
+				policy := *src.InternalTrafficPolicy
+				dst.InternalTrafficPolicy = &policy
+			}
+		},
+	},
+	{
+		name: "loadBalancerClass",
+		value: func(spec *corev1.ServiceSpec) string {
+			if spec.LoadBalancerClass == nil {
+				return ""
+			}
+			return *spec.LoadBalancerClass
+		},
+		set: func(dst, src *corev1.ServiceSpec) {
+			dst.LoadBalancerClass = nil
+			if src.LoadBalancerClass != nil {
+				class := *src.LoadBalancerClass
+				dst.LoadBalancerClass = &class
+			}
+		},
+	},
+	{
+		name:  "loadBalancerSourceRanges",
+		value: func(spec *corev1.ServiceSpec) string { return strings.Join(spec.LoadBalancerSourceRanges, ",") },
+		set: func(dst, src *corev1.ServiceSpec) {
+			dst.LoadBalancerSourceRanges = append([]string(nil), src.LoadBalancerSourceRanges...)
+		},
+	},
+	{
+		name:  "publishNotReadyAddresses",
+		value: func(spec *corev1.ServiceSpec) string { return strconv.FormatBool(spec.PublishNotReadyAddresses) },
+		set:   func(dst, src *corev1.ServiceSpec) { dst.PublishNotReadyAddresses = src.PublishNotReadyAddresses },
+	},
+}
+
+// desiredTrafficPolicy returns the traffic-policy fields the leader Service should have: the source Service's
+// values, replaced by valid zen-lead.io/leader-* overrides, and cleared where the leader Service type does not
+// allow them. Fields the API server defaults keep the current leader value when neither source nor override sets them.
+func (r *ServiceDirectorReconciler) desiredTrafficPolicy(svc *corev1.Service, leaderType corev1.ServiceType, current *corev1.ServiceSpec) *corev1.ServiceSpec {
+	desired := &corev1.ServiceSpec{}
+	for _, field := range mirroredTrafficFields {
+		field.set(desired, &svc.Spec)
+	}
+	r.applyTrafficPolicyOverrides(svc, desired)
+
+	if desired.SessionAffinity == "" {
+		desired.SessionAffinity = current.SessionAffinity
+	}
+	if desired.SessionAffinity != corev1.ServiceAffinityClientIP {
+		desired.SessionAffinityConfig = nil
+	} else if desired.SessionAffinityConfig == nil {
+		desired.SessionAffinityConfig = current.SessionAffinityConfig.DeepCopy()
+	}
+	if desired.InternalTrafficPolicy == nil {
+		desired.InternalTrafficPolicy = current.InternalTrafficPolicy
+	}
+	if leaderType != corev1.ServiceTypeNodePort && leaderType != corev1.ServiceTypeLoadBalancer {
+		desired.ExternalTrafficPolicy = ""
+	} else if desired.ExternalTrafficPolicy == "" {
+		desired.ExternalTrafficPolicy = current.ExternalTrafficPolicy
+	}
+	if leaderType != corev1.ServiceTypeLoadBalancer {
+		desired.LoadBalancerClass = nil
+		desired.LoadBalancerSourceRanges = nil
+	}
+	return desired
+}
+
+// applyTrafficPolicyOverrides applies the zen-lead.io/leader-* override annotations. Invalid overrides emit an
+// InvalidTrafficPolicyOverride Warning event and are ignored (the source Service's value is mirrored).
+func (r *ServiceDirectorReconciler) applyTrafficPolicyOverrides(svc *corev1.Service, desired *corev1.ServiceSpec) {
+	invalid := func(annotation, val, expected string) {
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "InvalidTrafficPolicyOverride",
+			fmt.Sprintf("Invalid %s %q (expected %s): mirroring the source Service", annotation, val, expected))
+	}
+	override := func(annotation string) (string, bool) {
+		val := strings.TrimSpace(svc.Annotations[annotation])
+		return val, val != ""
+	}
+
+	if val, ok := override(AnnotationLeaderSessionAffinityService); ok {
+		switch corev1.ServiceAffinity(val) {
+		case corev1.ServiceAffinityNone, corev1.ServiceAffinityClientIP:
+			desired.SessionAffinity = corev1.ServiceAffinity(val)
+		default:
+			invalid(AnnotationLeaderSessionAffinityService, val, "None or ClientIP")
+		}
+	}
+	if val, ok := override(AnnotationLeaderSessionAffinityTimeoutService); ok {
+		timeout, err := strconv.Atoi(val)
+		if err != nil || timeout < 1 || timeout > maxSessionAffinityTimeoutSeconds {
+			invalid(AnnotationLeaderSessionAffinityTimeoutService, val, fmt.Sprintf("seconds between 1 and %d", maxSessionAffinityTimeoutSeconds))
+		} else {
+			timeoutSeconds := int32(timeout)
+			desired.SessionAffinityConfig = &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeoutSeconds}}
+		}
+	}
+	if val, ok := override(AnnotationLeaderExternalTrafficPolicyService); ok {
+		switch corev1.ServiceExternalTrafficPolicy(val) {
+		case corev1.ServiceExternalTrafficPolicyCluster, corev1.ServiceExternalTrafficPolicyLocal:
+			desired.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicy(val)
+		default:
+			invalid(AnnotationLeaderExternalTrafficPolicyService, val, "Cluster or Local")
+		}
+	}
+	if val, ok := override(AnnotationLeaderInternalTrafficPolicyService); ok {
+		switch policy := corev1.ServiceInternalTrafficPolicy(val); policy {
+		case corev1.ServiceInternalTrafficPolicyCluster, corev1.ServiceInternalTrafficPolicyLocal:
+			desired.InternalTrafficPolicy = &policy
+		default:
+			invalid(AnnotationLeaderInternalTrafficPolicyService, val, "Cluster or Local")
+		}
+	}
+	if val, ok := override(AnnotationLeaderLoadBalancerClassService); ok {
+		desired.LoadBalancerClass = &val
+	}
+	if val, ok := override(AnnotationLeaderLoadBalancerSourceRangesService); ok {
+		ranges := splitCommaList(val)
+		valid := len(ranges) > 0
+		for _, cidr := range ranges {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			desired.LoadBalancerSourceRanges = ranges
+		} else {
+			invalid(AnnotationLeaderLoadBalancerSourceRangesService, val, "comma-separated CIDRs")
+		}
+	}
+	if val, ok := override(AnnotationLeaderPublishNotReadyAddressesService); ok {
+		publish, err := strconv.ParseBool(val)
+		if err != nil {
+			invalid(AnnotationLeaderPublishNotReadyAddressesService, val, "true or false")
+		} else {
+			desired.PublishNotReadyAddresses = publish
+		}
+	}
+}
+
+// reconcileTrafficPolicy sets the mirrored traffic-policy fields on the leader Service and records them in
+// zen-lead.io/last-applied-traffic-policy. For an existing leader Service of an unchanged type, a field whose
+// value differs from the recorded one was edited on the leader Service: it is reported as drift (log,
+// LeaderServiceDrift event, zen_lead_leader_service_drift_total) and corrected. loadBalancerClass of a
+// LoadBalancer Service cannot change in place: drift is only reported, and a source or override change
+// emits LeaderServiceRecreateRequired.
+func (r *ServiceDirectorReconciler) reconcileTrafficPolicy(svc, leaderService, original *corev1.Service, logger *sdklog.Logger) {
+	desired := r.desiredTrafficPolicy(svc, leaderService.Spec.Type, &leaderService.Spec)
+	var lastApplied map[string]string
+	if original != nil {
+		// Missing or unparsable (leader Service created by an older version): nothing to compare with
+		_ = json.Unmarshal([]byte(original.Annotations[AnnotationLastAppliedTrafficPolicy]), &lastApplied)
+	}
+	// Applied values, except for drift that cannot be corrected (keeps being reported)
+	applied := make(map[string]string, len(mirroredTrafficFields))
+	for _, field := range mirroredTrafficFields {
+		have, want := field.value(&leaderService.Spec), field.value(desired)
+		if have == want {
+			continue
+		}
+		// New leader Service or a type change (e.g. ClusterIP to LoadBalancer) - nothing drifted, just set it
+		if original == nil || original.Spec.Type != leaderService.Spec.Type {
+			field.set(&leaderService.Spec, desired)
+			continue
+		}
+
+		last, recorded := lastApplied[field.name]
+		drifted := recorded && have != last
+		locked := field.name == "loadBalancerClass" && leaderService.Spec.Type == corev1.ServiceTypeLoadBalancer
+		if !locked {
+			field.set(&leaderService.Spec, desired)
+		}
+		if !drifted {
+			// The source Service or an override changed
+			if locked {
+				r.Recorder.Event(svc, corev1.EventTypeWarning, "LeaderServiceRecreateRequired",
+					fmt.Sprintf("Leader service %s has %s %q, expected %q: cannot be changed in place, delete %s to recreate it",
+						leaderService.Name, field.name, have, want, leaderService.Name))
+			}
+			continue
+		}
+
+		outcome := "corrected"
+		if locked {
+			outcome = fmt.Sprintf("cannot be changed in place, delete %s to recreate it", leaderService.Name)
+			applied[field.name] = last
+		}
+		logger.Info("Leader service drifted from mirrored traffic policy",
+			sdklog.Operation("traffic_policy"),
+			sdklog.String("service", leaderService.Name),
+			sdklog.String("field", field.name),
+			sdklog.String("have", have),
+			sdklog.String("want", want))
+		r.Recorder.Event(svc, corev1.EventTypeWarning, "LeaderServiceDrift",
+			fmt.Sprintf("Leader service %s has %s %q, expected %q: %s", leaderService.Name, field.name, have, want, outcome))
+		if r.Metrics != nil {
+			r.Metrics.RecordLeaderServiceDrift(svc.Namespace, svc.Name, field.name)
+		}
+	}
+
+	for _, field := range mirroredTrafficFields {
+		if _, ok := applied[field.name]; !ok {
+			applied[field.name] = field.value(&leaderService.Spec)
+		}
+	}
+	data, err := json.Marshal(applied)
+	if err != nil {
+		return
+	}
+	if leaderService.Annotations == nil {
+		leaderService.Annotations = make(map[string]string)
+	}
+	leaderService.Annotations[AnnotationLastAppliedTrafficPolicy] = string(data)
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package director
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-lead/pkg/metrics"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newLoadBalancerService(annotations map[string]string) *corev1.Service {
+	annotations[AnnotationEnabledService] = "true"
+	local := corev1.ServiceInternalTrafficPolicyLocal
+	class := "internal-lb"
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default", Annotations: annotations},
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			Selector:                 map[string]string{"app": "my-app"},
+			Ports:                    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP}},
+			SessionAffinity:          corev1.ServiceAffinityNone,
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+			InternalTrafficPolicy:    &local,
+			LoadBalancerClass:        &class,
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+		},
+	}
+}
+
+func TestServiceDirectorReconciler_DesiredTrafficPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		leaderType  corev1.ServiceType
+		check       func(t *testing.T, desired *corev1.ServiceSpec)
+		expectEvent bool
+	}{
+		{
+			name:       "mirrors the source",
+			leaderType: corev1.ServiceTypeLoadBalancer,
+			check: func(t *testing.T, desired *corev1.ServiceSpec) {
+				if desired.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal {
+					t.Errorf("ExternalTrafficPolicy = %s, expected Local", desired.ExternalTrafficPolicy)
+				}
+				if desired.LoadBalancerClass == nil || *desired.LoadBalancerClass != "internal-lb" {
+					t.Errorf("LoadBalancerClass = %v, expected internal-lb", desired.LoadBalancerClass)
+				}
+				if !reflect.DeepEqual(desired.LoadBalancerSourceRanges, []string{"10.0.0.0/8"}) {
+					t.Errorf("LoadBalancerSourceRanges = %v", desired.LoadBalancerSourceRanges)
+				}
+			},
+		},
+		{
+			name: "overrides",
+			annotations: map[string]string{
+				AnnotationLeaderSessionAffinityService:          "ClientIP",
+				AnnotationLeaderSessionAffinityTimeoutService:   "600",
+				AnnotationLeaderExternalTrafficPolicyService:    "Cluster",
+				AnnotationLeaderLoadBalancerSourceRangesService: "192.168.0.0/16, 172.16.0.0/12",
+				AnnotationLeaderPublishNotReadyAddressesService: "true",
+			},
+			leaderType: corev1.ServiceTypeLoadBalancer,
+			check: func(t *testing.T, desired *corev1.ServiceSpec) {
+				if desired.SessionAffinity != corev1.ServiceAffinityClientIP {
+					t.Errorf("SessionAffinity = %s, expected ClientIP", desired.SessionAffinity)
+				}
+				if desired.SessionAffinityConfig == nil || *desired.SessionAffinityConfig.ClientIP.TimeoutSeconds != 600 {
+					t.Errorf("SessionAffinityConfig = %+v, expected 600s", desired.SessionAffinityConfig)
+				}
+				if desired.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyCluster {
+					t.Errorf("ExternalTrafficPolicy = %s, expected Cluster", desired.ExternalTrafficPolicy)
+				}
+				if !reflect.DeepEqual(desired.LoadBalancerSourceRanges, []string{"192.168.0.0/16", "172.16.0.0/12"}) {
+					t.Errorf("LoadBalancerSourceRanges = %v", desired.LoadBalancerSourceRanges)
+				}
+				if !desired.PublishNotReadyAddresses {
+					t.Error("PublishNotReadyAddresses = false, expected true")
+				}
+			},
+		},
+		{
+			name:        "invalid override mirrors the source",
+			annotations: map[string]string{AnnotationLeaderLoadBalancerSourceRangesService: "10.0.0.0/8,not-a-cidr"},
+			leaderType:  corev1.ServiceTypeLoadBalancer,
+			check: func(t *testing.T, desired *corev1.ServiceSpec) {
+				if !reflect.DeepEqual(desired.LoadBalancerSourceRanges, []string{"10.0.0.0/8"}) {
+					t.Errorf("LoadBalancerSourceRanges = %v, expected the source ranges", desired.LoadBalancerSourceRanges)
+				}
+			},
+			expectEvent: true,
+		},
+		{
+			name:       "ClusterIP leader drops load balancer fields",
+			leaderType: corev1.ServiceTypeClusterIP,
+			check: func(t *testing.T, desired *corev1.ServiceSpec) {
+				if desired.ExternalTrafficPolicy != "" || desired.LoadBalancerClass != nil || desired.LoadBalancerSourceRanges != nil {
+					t.Errorf("expected no external traffic policy or load balancer fields, got %+v", desired)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			svc := newLoadBalancerService(annotations)
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &ServiceDirectorReconciler{Recorder: eventRecorder}
+
+			tt.check(t, r.desiredTrafficPolicy(svc, tt.leaderType, &corev1.ServiceSpec{}))
+			gotEvent := false
+			for len(eventRecorder.Events) > 0 {
+				if strings.Contains(<-eventRecorder.Events, "InvalidTrafficPolicyOverride") {
+					gotEvent = true
+				}
+			}
+			if gotEvent != tt.expectEvent {
+				t.Errorf("InvalidTrafficPolicyOverride event = %v, expected %v", gotEvent, tt.expectEvent)
+			}
+		})
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_TrafficPolicyDrift(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := newLoadBalancerService(map[string]string{})
+	pod := newActivePod("pod-a", "10.0.0.1", time.Hour, 8080)
+	eventRecorder := record.NewFakeRecorder(100)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, pod).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	leaderKey := types.NamespacedName{Name: "my-service-leader", Namespace: "default"}
+	reconcileLeaderService := func() (*corev1.Service, []string) {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		leaderService := &corev1.Service{}
+		if err := r.Get(context.Background(), leaderKey, leaderService); err != nil {
+			t.Fatalf("failed to get leader service: %v", err)
+		}
+		var drift []string
+		for len(eventRecorder.Events) > 0 {
+			if event := <-eventRecorder.Events; strings.Contains(event, "LeaderServiceDrift") {
+				drift = append(drift, event)
+			}
+		}
+		return leaderService, drift
+	}
+
+	leaderService, drift := reconcileLeaderService()
+	if len(drift) != 0 {
+		t.Errorf("unexpected drift on creation: %v", drift)
+	}
+	if leaderService.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal ||
+		leaderService.Spec.LoadBalancerClass == nil || *leaderService.Spec.LoadBalancerClass != "internal-lb" ||
+		!reflect.DeepEqual(leaderService.Spec.LoadBalancerSourceRanges, []string{"10.0.0.0/8"}) {
+		t.Fatalf("leader service did not mirror the traffic policy: %+v", leaderService.Spec)
+	}
+
+	// Someone opens the leader Service up - drift is reported and corrected
+	leaderService.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+	leaderService.Spec.LoadBalancerSourceRanges = nil
+	if err := r.Update(context.Background(), leaderService); err != nil {
+		t.Fatalf("failed to update leader service: %v", err)
+	}
+	leaderService, drift = reconcileLeaderService()
+	if len(drift) != 2 {
+		t.Errorf("expected 2 LeaderServiceDrift events, got %v", drift)
+	}
+	if leaderService.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal ||
+		!reflect.DeepEqual(leaderService.Spec.LoadBalancerSourceRanges, []string{"10.0.0.0/8"}) {
+		t.Errorf("drift not corrected: %+v", leaderService.Spec)
+	}
+
+	// loadBalancerClass cannot change in place - reported, not written
+	other := "public-lb"
+	leaderService.Spec.LoadBalancerClass = &other
+	if err := r.Update(context.Background(), leaderService); err != nil {
+		t.Fatalf("failed to update leader service: %v", err)
+	}
+	leaderService, drift = reconcileLeaderService()
+	if len(drift) != 1 || !strings.Contains(drift[0], "cannot be changed in place") {
+		t.Errorf("expected an in-place change warning, got %v", drift)
+	}
+	if *leaderService.Spec.LoadBalancerClass != other {
+		t.Errorf("LoadBalancerClass = %s, expected it to be left alone", *leaderService.Spec.LoadBalancerClass)
+	}
+	// Uncorrected drift keeps being reported
+	if _, drift = reconcileLeaderService(); len(drift) != 1 {
+		t.Errorf("expected the loadBalancerClass drift to be reported again, got %v", drift)
+	}
+}
+
+func TestServiceDirectorReconciler_Reconcile_TrafficPolicySourceChange(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = discoveryv1.AddToScheme(scheme)
+
+	svc := newLoadBalancerService(map[string]string{})
+	pod := newActivePod("pod-a", "10.0.0.1", time.Hour, 8080)
+	eventRecorder := record.NewFakeRecorder(100)
+	r := &ServiceDirectorReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc, pod).Build(),
+		Scheme:   scheme,
+		Recorder: eventRecorder,
+		Metrics:  metrics.NewRecorder(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}}
+	leaderKey := types.NamespacedName{Name: "my-service-leader", Namespace: "default"}
+	reconcileLeaderService := func() (*corev1.Service, []string) {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		leaderService := &corev1.Service{}
+		if err := r.Get(context.Background(), leaderKey, leaderService); err != nil {
+			t.Fatalf("failed to get leader service: %v", err)
+		}
+		var warnings []string
+		for len(eventRecorder.Events) > 0 {
+			if event := <-eventRecorder.Events; strings.Contains(event, "LeaderServiceDrift") || strings.Contains(event, "LeaderServiceRecreateRequired") {
+				warnings = append(warnings, event)
+			}
+		}
+		return leaderService, warnings
+	}
+	updateSource := func(mutate func(*corev1.Service)) {
+		t.Helper()
+		current := &corev1.Service{}
+		if err := r.Get(context.Background(), req.NamespacedName, current); err != nil {
+			t.Fatalf("failed to get service: %v", err)
+		}
+		mutate(current)
+		if err := r.Update(context.Background(), current); err != nil {
+			t.Fatalf("failed to update service: %v", err)
+		}
+	}
+
+	reconcileLeaderService()
+
+	// Source Service and override edits are ordinary updates, not drift
+	updateSource(func(s *corev1.Service) {
+		s.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+		s.Annotations[AnnotationLeaderLoadBalancerSourceRangesService] = "192.168.0.0/16"
+	})
+	leaderService, warnings := reconcileLeaderService()
+	if len(warnings) != 0 {
+		t.Errorf("source change reported as drift: %v", warnings)
+	}
+	if leaderService.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyCluster ||
+		!reflect.DeepEqual(leaderService.Spec.LoadBalancerSourceRanges, []string{"192.168.0.0/16"}) {
+		t.Errorf("source change not applied: %+v", leaderService.Spec)
+	}
+
+	// A loadBalancerClass change on the source cannot be applied in place - it asks for a recreate, not drift
+	updateSource(func(s *corev1.Service) {
+		class := "public-lb"
+		s.Spec.LoadBalancerClass = &class
+	})
+	leaderService, warnings = reconcileLeaderService()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "LeaderServiceRecreateRequired") {
+		t.Errorf("expected a LeaderServiceRecreateRequired event, got %v", warnings)
+	}
+	if *leaderService.Spec.LoadBalancerClass != "internal-lb" {
+		t.Errorf("LoadBalancerClass = %s, expected it to be left alone", *leaderService.Spec.LoadBalancerClass)
+	}
+}
@@ -55,6 +55,14 @@ type Recorder struct {
 	timeoutOccurrencesTotal       *prometheus.CounterVec
 	failoverLatencySeconds        *prometheus.HistogramVec
 	apiCallDurationSeconds        *prometheus.HistogramVec
+	roleProbesTotal               *prometheus.CounterVec
+	roleProbeDurationSeconds      *prometheus.HistogramVec
+	roleProbesInFlight            prometheus.Gauge
+	rolePrimaryPods               *prometheus.GaugeVec
+	failoverSuppressedTotal       *prometheus.CounterVec
+	failoverCircuitOpen           *prometheus.GaugeVec
+	quorumMet                     *prometheus.GaugeVec
+	leaderServiceDriftTotal       *prometheus.CounterVec
 }
 
 var (
@@ -107,7 +115,7 @@ func NewRecorder() *Recorder {
 				Name: "zen_lead_failover_count_total",
 				Help: "Total number of leader failovers (leader changes)",
 			},
-			[]string{"namespace", "service", "reason"}, // reason: notReady, terminating, noIP, noneReady
+			[]string{"namespace", "service", "reason"}, // reason: notReady, terminating, noIP, noneReady, circuitOpen, quorumLost, preempted, nodeUnsafe, pinned, switchover, roleChanged, leaseChanged, labelChanged
 		),
 
 		// Reconciliation duration: duration of reconciliation loops (zen-lead specific with namespace/service labels)
@@ -320,6 +328,78 @@ func NewRecorder() *Recorder {
 			},
 			[]string{"namespace", "service", "operation", "result"}, // operation: get, list, create, patch, delete, result: success, error
 		),
+
+		// Role probes: application role probes per pod (zen-lead.io/role-probe)
+		roleProbesTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_lead_role_probes_total",
+				Help: "Total number of application role probes",
+			},
+			[]string{"namespace", "service", "result"}, // result: primary, replica, error, timeout
+		),
+
+		// Role probe duration: latency of a single role probe
+		roleProbeDurationSeconds: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "zen_lead_role_probe_duration_seconds",
+				Help:    "Duration of application role probes in seconds",
+				Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0},
+			},
+			[]string{"namespace", "service"},
+		),
+
+		// Role probes in flight: concurrent role probes across all Services
+		roleProbesInFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "zen_lead_role_probes_in_flight",
+				Help: "Number of application role probes currently in flight",
+			},
+		),
+
+		// Role primary pods: pods reporting the primary role in the last probe round (1 = healthy)
+		rolePrimaryPods: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "zen_lead_role_primary_pods",
+				Help: "Number of pods reporting the primary role in the last role probe round",
+			},
+			[]string{"namespace", "service"},
+		),
+
+		// Failover suppressed: leader changes blocked by the flap circuit breaker
+		failoverSuppressedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_lead_failover_suppressed_total",
+				Help: "Total number of leader changes suppressed by the failover circuit breaker",
+			},
+			[]string{"namespace", "service", "mode"}, // mode: hold, empty
+		),
+
+		// Failover circuit open: 1 while the circuit breaker suppresses leader changes
+		failoverCircuitOpen: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "zen_lead_failover_circuit_open",
+				Help: "Whether the failover circuit breaker is open (1) or closed (0)",
+			},
+			[]string{"namespace", "service"},
+		),
+
+		// Quorum met: whether zen-lead.io/min-ready-pods is satisfied (only for Services that set it)
+		quorumMet: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "zen_lead_quorum_met",
+				Help: "Whether at least zen-lead.io/min-ready-pods pods are Ready (1) or not (0)",
+			},
+			[]string{"namespace", "service"},
+		),
+
+		// Leader Service drift: mirrored traffic-policy fields found changed on the leader Service
+		leaderServiceDriftTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_lead_leader_service_drift_total",
+				Help: "Total number of mirrored traffic-policy fields found drifted on the leader Service",
+			},
+			[]string{"namespace", "service", "field"},
+		),
 	}
 
 	// Register all zen-lead-specific metrics with controller-runtime registry
@@ -350,6 +430,14 @@ func NewRecorder() *Recorder {
 		recorder.timeoutOccurrencesTotal,
 		recorder.failoverLatencySeconds,
 		recorder.apiCallDurationSeconds,
+		recorder.roleProbesTotal,
+		recorder.roleProbeDurationSeconds,
+		recorder.roleProbesInFlight,
+		recorder.rolePrimaryPods,
+		recorder.failoverSuppressedTotal,
+		recorder.failoverCircuitOpen,
+		recorder.quorumMet,
+		recorder.leaderServiceDriftTotal,
 	)
 
 	globalRecorder = recorder
@@ -511,6 +599,55 @@ func (r *Recorder) RecordAPICallDuration(namespace, service, operation, result s
 	r.apiCallDurationSeconds.WithLabelValues(namespace, service, operation, result).Observe(durationSeconds)
 }
 
+// RecordRoleProbe records the result and duration of an application role probe
+func (r *Recorder) RecordRoleProbe(namespace, service, result string, durationSeconds float64) {
+	r.roleProbesTotal.WithLabelValues(namespace, service, result).Inc()
+	r.roleProbeDurationSeconds.WithLabelValues(namespace, service).Observe(durationSeconds)
+}
+
+// IncRoleProbesInFlight increments the number of role probes in flight
+func (r *Recorder) IncRoleProbesInFlight() {
+	r.roleProbesInFlight.Inc()
+}
+
+// DecRoleProbesInFlight decrements the number of role probes in flight
+func (r *Recorder) DecRoleProbesInFlight() {
+	r.roleProbesInFlight.Dec()
+}
+
+// RecordRolePrimaryPods records how many pods reported the primary role
+func (r *Recorder) RecordRolePrimaryPods(namespace, service string, count int) {
+	r.rolePrimaryPods.WithLabelValues(namespace, service).Set(float64(count))
+}
+
+// RecordFailoverSuppressed records a leader change suppressed by the failover circuit breaker
+func (r *Recorder) RecordFailoverSuppressed(namespace, service, mode string) {
+	r.failoverSuppressedTotal.WithLabelValues(namespace, service, mode).Inc()
+}
+
+// RecordFailoverCircuitOpen records whether the failover circuit breaker is open
+func (r *Recorder) RecordFailoverCircuitOpen(namespace, service string, open bool) {
+	value := 0.0
+	if open {
+		value = 1.0
+	}
+	r.failoverCircuitOpen.WithLabelValues(namespace, service).Set(value)
+}
+
+// RecordQuorumMet records whether the min-ready-pods quorum is met
+func (r *Recorder) RecordQuorumMet(namespace, service string, met bool) {
+	value := 0.0
+	if met {
+		value = 1.0
+	}
+	r.quorumMet.WithLabelValues(namespace, service).Set(value)
+}
+
+// RecordLeaderServiceDrift records a mirrored field found drifted on the leader Service
+func (r *Recorder) RecordLeaderServiceDrift(namespace, service, field string) {
+	r.leaderServiceDriftTotal.WithLabelValues(namespace, service, field).Inc()
+}
+
 // Exported getters for testing (access to metric vectors)
 
 // PodsAvailable returns the pods available gauge vector (for testing)
@@ -547,3 +684,23 @@ func (r *Recorder) APICallDurationSeconds() *prometheus.HistogramVec {
 func (r *Recorder) PortResolutionFailuresTotal() *prometheus.CounterVec {
 	return r.portResolutionFailuresTotal
 }
+
+// RoleProbesTotal returns the role probes counter vector (for testing)
+func (r *Recorder) RoleProbesTotal() *prometheus.CounterVec {
+	return r.roleProbesTotal
+}
+
+// FailoverSuppressedTotal returns the failover suppressed counter vector (for testing)
+func (r *Recorder) FailoverSuppressedTotal() *prometheus.CounterVec {
+	return r.failoverSuppressedTotal
+}
+
+// QuorumMet returns the quorum met gauge vector (for testing)
+func (r *Recorder) QuorumMet() *prometheus.GaugeVec {
+	return r.quorumMet
+}
+
+// LeaderServiceDriftTotal returns the leader Service drift counter vector (for testing)
+func (r *Recorder) LeaderServiceDriftTotal() *prometheus.CounterVec {
+	return r.leaderServiceDriftTotal
+}
@@ -272,6 +272,68 @@ func TestRecordAPICallDuration(t *testing.T) {
 	}
 }
 
+func TestRecordRoleProbeMetrics(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.IncRoleProbesInFlight()
+	recorder.RecordRoleProbe("default", "my-service", "primary", 0.01)
+	recorder.RecordRoleProbe("default", "my-service", "timeout", 1.0)
+	recorder.DecRoleProbesInFlight()
+	recorder.RecordRolePrimaryPods("default", "my-service", 1)
+
+	// Verify metric was recorded
+	metric, err := recorder.RoleProbesTotal().GetMetricWithLabelValues("default", "my-service", "timeout")
+	if err != nil {
+		t.Fatalf("Failed to get metric: %v", err)
+	}
+	if counter, ok := metric.(prometheus.Counter); !ok || counter == nil {
+		t.Fatal("Metric is not a Counter")
+	}
+}
+
+func TestRecordFailoverSuppressed(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.RecordFailoverSuppressed("default", "my-service", "hold")
+	recorder.RecordFailoverCircuitOpen("default", "my-service", true)
+	recorder.RecordFailoverCircuitOpen("default", "my-service", false)
+
+	// Verify metric was recorded
+	metric, err := recorder.FailoverSuppressedTotal().GetMetricWithLabelValues("default", "my-service", "hold")
+	if err != nil {
+		t.Fatalf("Failed to get metric: %v", err)
+	}
+	if counter, ok := metric.(prometheus.Counter); !ok || counter == nil {
+		t.Fatal("Metric is not a Counter")
+	}
+}
+
+func TestRecordQuorumMet(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.RecordQuorumMet("default", "my-service", false)
+
+	// Verify metric was recorded
+	metric, err := recorder.QuorumMet().GetMetricWithLabelValues("default", "my-service")
+	if err != nil {
+		t.Fatalf("Failed to get metric: %v", err)
+	}
+	if gauge, ok := metric.(prometheus.Gauge); !ok || gauge == nil {
+		t.Fatal("Metric is not a Gauge")
+	}
+}
+
+func TestRecordLeaderServiceDrift(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.RecordLeaderServiceDrift("default", "my-service", "externalTrafficPolicy")
+
+	// Verify metric was recorded
+	metric, err := recorder.LeaderServiceDriftTotal().GetMetricWithLabelValues("default", "my-service", "externalTrafficPolicy")
+	if err != nil {
+		t.Fatalf("Failed to get metric: %v", err)
+	}
+	if counter, ok := metric.(prometheus.Counter); !ok || counter == nil {
+		t.Fatal("Metric is not a Counter")
+	}
+}
+
 func TestMetricsEdgeCases(t *testing.T) {
 	recorder := NewRecorder()
 